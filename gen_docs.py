@@ -15,10 +15,14 @@ with open("schema.json", "r") as f:
 # Create the docs directory structure
 os.makedirs("docs/data-sources", exist_ok=True)
 os.makedirs("docs/resources", exist_ok=True)
+os.makedirs("docs/ephemeral-resources", exist_ok=True)
+os.makedirs("docs/functions", exist_ok=True)
 
-# Initialize lists to keep track of resources and data sources
+# Initialize lists to keep track of resources, data sources, ephemeral resources and functions
 resources = []
 data_sources = []
+ephemeral_resources = []
+functions = []
 
 # Function to recursively process nested attributes and blocks
 def process_attributes(attributes, indent_level=0):
@@ -92,10 +96,13 @@ def generate_example_value(attr_name, attr_schema, indent=0):
 
 # Function to generate a Markdown file for a resource or data source
 # Function to generate a Markdown file for a resource or data source
-def generate_markdown(file_path, name, description, attributes, is_resource=True):
+def generate_markdown(file_path, name, description, attributes, is_resource=True, is_ephemeral=False):
     with open(file_path, "w") as f:
         # Title
-        f.write(f"# {'Resource' if is_resource else 'Data Source'}: {name}\n\n")
+        if is_ephemeral:
+            f.write(f"# Ephemeral Resource: {name}\n\n")
+        else:
+            f.write(f"# {'Resource' if is_resource else 'Data Source'}: {name}\n\n")
 
         # Description
         f.write(f"## Description\n\n{description}\n\n")
@@ -103,7 +110,9 @@ def generate_markdown(file_path, name, description, attributes, is_resource=True
         # Example Usage
         f.write("## Example Usage\n\n")
         f.write("```hcl\n")
-        if is_resource:
+        if is_ephemeral:
+            f.write(f'ephemeral "{name}" "example" {{\n')
+        elif is_resource:
             f.write(f'resource "{name}" "example" {{\n')
         else:
             f.write(f'data "{name}" "example" {{\n')
@@ -117,6 +126,39 @@ def generate_markdown(file_path, name, description, attributes, is_resource=True
         f.write("## Argument Reference\n\n")
         f.write(process_attributes(attributes))
 
+# Function to describe a type of the schema, e.g. "string" or ["list", "string"]
+def type_name(type_schema):
+    if isinstance(type_schema, str):
+        return type_schema
+    if type_schema[0] == "object":
+        return "object({" + ", ".join(f"{name} = {type_name(t)}" for name, t in type_schema[1].items()) + "})"
+    return f"{type_schema[0]}({type_name(type_schema[1])})"
+
+# Function to generate a Markdown file for a provider function
+def generate_function_markdown(file_path, provider, name, function_schema):
+    parameters = function_schema.get("parameters", [])
+    with open(file_path, "w") as f:
+        f.write(f"# Function: {name}\n\n")
+        f.write(f"## Description\n\n{function_schema.get('description', 'No description available.')}\n\n")
+
+        f.write("## Example Usage\n\n")
+        f.write("```hcl\n")
+        f.write("output \"example\" {\n")
+        arguments = ", ".join('{}' if p['type'] == "dynamic" else '"example string"' for p in parameters)
+        f.write(f"  value = provider::{provider}::{name}({arguments})\n")
+        f.write("}\n```\n\n")
+
+        f.write("## Signature\n\n")
+        f.write("```text\n")
+        signature = ", ".join(f"{p['name']} {type_name(p['type'])}" for p in parameters)
+        f.write(f"{name}({signature}) {type_name(function_schema['return_type'])}\n")
+        f.write("```\n\n")
+
+        f.write("## Arguments\n\n")
+        for parameter in parameters:
+            f.write(f"- `{parameter['name']}` - {parameter.get('description', 'No description available.')}\n")
+            f.write(f"  ({type_name(parameter['type'])}{', nullable' if parameter.get('is_nullable', False) else ''})\n")
+
 # Generate documentation for each provider
 for provider_name, provider_schema in schema["provider_schemas"].items():
     # Generate resource documentation
@@ -141,6 +183,27 @@ for provider_name, provider_schema in schema["provider_schemas"].items():
             is_resource=False,
         )
 
+    # Generate ephemeral resource documentation
+    for ephemeral_resource_name, ephemeral_resource_schema in provider_schema.get("ephemeral_resource_schemas", {}).items():
+        ephemeral_resources.append(ephemeral_resource_name)
+        generate_markdown(
+            file_path=f"docs/ephemeral-resources/{ephemeral_resource_name}.md",
+            name=ephemeral_resource_name,
+            description=ephemeral_resource_schema.get("description", "No description available."),
+            attributes=ephemeral_resource_schema["block"]["attributes"],
+            is_ephemeral=True,
+        )
+
+    # Generate function documentation
+    for function_name, function_schema in provider_schema.get("functions", {}).items():
+        functions.append(function_name)
+        generate_function_markdown(
+            file_path=f"docs/functions/{function_name}.md",
+            provider=provider_name.split("/")[-1],
+            name=function_name,
+            function_schema=function_schema,
+        )
+
 # Generate the index.md file
 with open("docs/index.md", "w") as f:
     f.write(f"# Provider: {provider_name}\n\n")
@@ -150,5 +213,11 @@ with open("docs/index.md", "w") as f:
     f.write("\n## Data Sources\n\n")
     for data_source in data_sources:
         f.write(f"- [{data_source}](data-sources/{data_source}.md)\n")
+    f.write("\n## Ephemeral Resources\n\n")
+    for ephemeral_resource in ephemeral_resources:
+        f.write(f"- [{ephemeral_resource}](ephemeral-resources/{ephemeral_resource}.md)\n")
+    f.write("\n## Functions\n\n")
+    for function in functions:
+        f.write(f"- [{function}](functions/{function}.md)\n")
     with open("README.md", "r") as r:
         f.write(r.read()+"\n\n")
\ No newline at end of file
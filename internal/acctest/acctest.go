@@ -0,0 +1,56 @@
+// Package acctest runs the provider against testserver, so resource.UnitTest can cover whole Terraform runs without
+// credentials for the live marketplace.
+package acctest
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"terraform-provider-otc-marketplace/internal/provider_marketplace"
+	"terraform-provider-otc-marketplace/internal/testserver"
+	"testing"
+)
+
+// Credentials the test server is started with, and that ProviderConfig logs in with
+const (
+	DomainName = "OTC-EU-DE-00000000001000000001"
+	Username   = "seller"
+	Password   = "secret"
+)
+
+// ProtoV6ProviderFactories serves the provider in-process, set it as resource.TestCase.ProtoV6ProviderFactories
+var ProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"otc-marketplace": providerserver.NewProtocol6WithError(provider_marketplace.New()()),
+}
+
+// NewServer starts a test server that accepts the credentials above, and closes it once the test is done
+func NewServer(t *testing.T, opts testserver.Options) *testserver.Server {
+	t.Helper()
+
+	if opts.DomainName == "" {
+		opts.DomainName = DomainName
+	}
+	if opts.Username == "" {
+		opts.Username = Username
+	}
+	if opts.Password == "" {
+		opts.Password = Password
+	}
+	server := testserver.New(opts)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// ProviderConfig configures the provider for server, to be prepended to the configuration of a test step. Retries are
+// disabled, so injected faults fail right away.
+func ProviderConfig(server *testserver.Server) string {
+	return fmt.Sprintf(`
+provider "otc-marketplace" {
+  endpoint    = %q
+  domain_name = %q
+  username    = %q
+  password    = %q
+  max_retries = 0
+}
+`, server.BaseURL(), DomainName, Username, Password)
+}
@@ -0,0 +1,115 @@
+package datasource_applications
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+)
+
+// applicationsDataSourceModel adds the filters to the generated model
+type applicationsDataSourceModel struct {
+	ApplicationsModel
+	ProductId              types.String `tfsdk:"product_id"`
+	ProductRevisionId      types.String `tfsdk:"product_revision_id"`
+	ProjectId              types.String `tfsdk:"project_id"`
+	ClusterId              types.String `tfsdk:"cluster_id"`
+	Namespace              types.String `tfsdk:"namespace"`
+	State                  types.String `tfsdk:"state"`
+	IncludeProductRevision types.Bool   `tfsdk:"include_product_revision"`
+}
+
+// applicationsSchema adds the optional filters to the generated schema, an application has to match all of them
+func applicationsSchema(ctx context.Context) schema.Schema {
+	s := ApplicationsDataSourceSchema(ctx)
+
+	s.Attributes["product_id"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only return deployments of this product",
+	}
+	s.Attributes["product_revision_id"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only return deployments of this product revision",
+	}
+	s.Attributes["project_id"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only return applications deployed to this OTC project",
+	}
+	s.Attributes["cluster_id"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only return applications deployed to this CCE cluster",
+	}
+	s.Attributes["namespace"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only return applications deployed to this namespace",
+	}
+	s.Attributes["state"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only return applications in this deployment state, one of `pending`, `ready` or `error`",
+		Validators: []validator.String{stringvalidator.OneOf(
+			string(sellerapi.ApplicationStatePending),
+			string(sellerapi.ApplicationStateReady),
+			string(sellerapi.ApplicationStateError),
+		)},
+	}
+	s.Attributes["include_product_revision"] = schema.BoolAttribute{
+		Optional: true,
+		Description: "Set to `false` to leave `product_revision` null, which skips mapping its icon, documents and " +
+			"configuration template for every application. Defaults to `true`.",
+	}
+
+	return s
+}
+
+// applicationsFilter matches applications against the filters that are set
+type applicationsFilter struct {
+	productId         *string
+	productRevisionId *string
+	projectId         *string
+	clusterId         *string
+	namespace         *string
+	state             *sellerapi.ApplicationState
+}
+
+func newApplicationsFilter(data applicationsDataSourceModel) applicationsFilter {
+	var filter applicationsFilter
+
+	if !data.ProductId.IsNull() {
+		filter.productId = data.ProductId.ValueStringPointer()
+	}
+	if !data.ProductRevisionId.IsNull() {
+		filter.productRevisionId = data.ProductRevisionId.ValueStringPointer()
+	}
+	if !data.ProjectId.IsNull() {
+		filter.projectId = data.ProjectId.ValueStringPointer()
+	}
+	if !data.ClusterId.IsNull() {
+		filter.clusterId = data.ClusterId.ValueStringPointer()
+	}
+	if !data.Namespace.IsNull() {
+		filter.namespace = data.Namespace.ValueStringPointer()
+	}
+	if !data.State.IsNull() {
+		state := sellerapi.ApplicationState(data.State.ValueString())
+		filter.state = &state
+	}
+
+	return filter
+}
+
+func (f applicationsFilter) matches(application sellerapi.Application) bool {
+	// product_revision_id isn't always set next to the embedded revision
+	revisionId := application.ProductRevisionId
+	if revisionId == "" {
+		revisionId = application.ProductRevision.Id
+	}
+
+	return (f.productId == nil || application.Product.Id == *f.productId) &&
+		(f.productRevisionId == nil || revisionId == *f.productRevisionId) &&
+		(f.projectId == nil || application.ProjectId == *f.projectId) &&
+		(f.clusterId == nil || application.ClusterId == *f.clusterId) &&
+		(f.namespace == nil || application.Namespace == *f.namespace) &&
+		(f.state == nil || application.State == *f.state)
+}
@@ -0,0 +1,92 @@
+package datasource_applications
+
+import (
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+	"terraform-provider-otc-marketplace/internal/util"
+	"testing"
+)
+
+func TestApplicationsFilterMatches(t *testing.T) {
+	application := sellerapi.Application{
+		ProjectId:         "project",
+		ClusterId:         "cluster",
+		Namespace:         "namespace",
+		State:             sellerapi.ApplicationStateReady,
+		ProductRevisionId: "revision",
+		Product:           sellerapi.Product{Id: "product"},
+		ProductRevision:   sellerapi.ProductRevision{Id: "revision"},
+	}
+	// Only the embedded revision has the id
+	embeddedOnly := application
+	embeddedOnly.ProductRevisionId = ""
+	// Neither has an id
+	noRevision := embeddedOnly
+	noRevision.ProductRevision = sellerapi.ProductRevision{}
+	// product_revision_id wins over the embedded revision
+	mismatched := application
+	mismatched.ProductRevision = sellerapi.ProductRevision{Id: "embedded"}
+
+	tests := []struct {
+		name              string
+		productId         string
+		productRevisionId string
+		projectId         string
+		clusterId         string
+		namespace         string
+		state             string
+		application       sellerapi.Application
+		want              bool
+	}{
+		{name: "no filters", application: application, want: true},
+		{name: "no filters without revision", application: noRevision, want: true},
+		{name: "product_revision_id", productRevisionId: "revision", application: application, want: true},
+		{name: "other product_revision_id", productRevisionId: "other", application: application, want: false},
+		{name: "falls back to the embedded revision", productRevisionId: "revision", application: embeddedOnly, want: true},
+		{name: "other embedded revision", productRevisionId: "other", application: embeddedOnly, want: false},
+		{name: "no revision id", productRevisionId: "revision", application: noRevision, want: false},
+		{name: "product_revision_id over the embedded revision", productRevisionId: "revision", application: mismatched, want: true},
+		{name: "embedded revision is ignored if product_revision_id is set", productRevisionId: "embedded", application: mismatched, want: false},
+		{name: "product", productId: "product", application: application, want: true},
+		{name: "other product", productId: "other", application: application, want: false},
+		{name: "state", state: "ready", application: application, want: true},
+		{name: "other state", state: "error", application: application, want: false},
+		{
+			name:      "all filters",
+			productId: "product", productRevisionId: "revision", projectId: "project", clusterId: "cluster",
+			namespace: "namespace", state: "ready",
+			application: application, want: true,
+		},
+		{
+			name:      "all filters with the embedded revision",
+			productId: "product", productRevisionId: "revision", projectId: "project", clusterId: "cluster",
+			namespace: "namespace", state: "ready",
+			application: embeddedOnly, want: true,
+		},
+		{
+			name:      "all filters but the project",
+			productId: "product", productRevisionId: "revision", projectId: "other", clusterId: "cluster",
+			namespace: "namespace", state: "ready",
+			application: application, want: false,
+		},
+		{name: "cluster and other namespace", clusterId: "cluster", namespace: "other", application: application, want: false},
+		{name: "product and other state", productId: "product", state: "pending", application: application, want: false},
+		{name: "project and other revision", projectId: "project", productRevisionId: "other", application: embeddedOnly, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := newApplicationsFilter(applicationsDataSourceModel{
+				ProductId:         util.StringSetOrNull(tt.productId),
+				ProductRevisionId: util.StringSetOrNull(tt.productRevisionId),
+				ProjectId:         util.StringSetOrNull(tt.projectId),
+				ClusterId:         util.StringSetOrNull(tt.clusterId),
+				Namespace:         util.StringSetOrNull(tt.namespace),
+				State:             util.StringSetOrNull(tt.state),
+			})
+
+			if got := filter.matches(tt.application); got != tt.want {
+				t.Errorf("expected %t, got %t", tt.want, got)
+			}
+		})
+	}
+}
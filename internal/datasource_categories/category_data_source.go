@@ -0,0 +1,187 @@
+package datasource_categories
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+	"terraform-provider-otc-marketplace/internal/util"
+)
+
+var _ datasource.DataSource = (*categoryDataSource)(nil)
+var _ datasource.DataSourceWithConfigValidators = (*categoryDataSource)(nil)
+
+// NewCategoryDataSource looks up a single category by its id or name
+func NewCategoryDataSource() datasource.DataSource {
+	return &categoryDataSource{}
+}
+
+type categoryDataSource struct {
+	client *sellerapi.Client
+}
+
+type categoryDataSourceModel struct {
+	Description types.String `tfsdk:"description"`
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Position    types.Int64  `tfsdk:"position"`
+	State       types.String `tfsdk:"state"`
+}
+
+func (d *categoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_category"
+}
+
+// Schema reuses the attributes of a category in the generated categories schema, only id and name can be set
+func (d *categoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	categories, ok := CategoriesDataSourceSchema(ctx).Attributes["categories"].(schema.SetNestedAttribute)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected categories schema", "categories is not a set of nested attributes")
+		return
+	}
+
+	attributes := map[string]schema.Attribute{}
+	for name, attribute := range categories.NestedObject.Attributes {
+		attributes[name] = attribute
+	}
+	attributes["id"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "NanoID of the category, either `id` or `name` has to be set",
+	}
+	attributes["name"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Exact name of the category, e.g. `Monitoring`, either `id` or `name` has to be set",
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "A single category, looked up by its id or name. " +
+			"Before this release, this data source listed all categories, which is now `otc-marketplace_categories`. " +
+			"See the CHANGELOG for upgrading.",
+		Attributes: attributes,
+	}
+}
+
+func (d *categoryDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+	}
+}
+
+func (d *categoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	clientPTR, ok := req.ProviderData.(*sellerapi.Client)
+	if !ok || clientPTR == nil {
+		resp.Diagnostics.AddError(
+			"Provider Configuration Error",
+			"The provider was not configured correctly, or the API client is missing.",
+		)
+		return
+	}
+	d.client = clientPTR
+}
+
+func (d *categoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data categoryDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var category *sellerapi.Category
+	if !data.Id.IsNull() {
+		category = d.categoryById(ctx, data.Id.ValueString(), &resp.Diagnostics)
+	} else {
+		category = d.categoryByName(ctx, data.Name.ValueString(), &resp.Diagnostics)
+	}
+	if category == nil {
+		return
+	}
+
+	data = categoryDataSourceModel{
+		Description: types.StringValue(category.Description),
+		Id:          types.StringValue(category.Id),
+		Name:        types.StringValue(category.Name),
+		Position:    types.Int64Value(category.Position),
+		State:       types.StringValue(string(category.State)),
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *categoryDataSource) categoryById(ctx context.Context, id string, diags *diag.Diagnostics) *sellerapi.Category {
+	category, err := d.client.GetCategory(ctx, util.SanitizeString(id))
+	if util.IsNotFound(err) {
+		diags.AddAttributeError(path.Root("id"), "Category not found", fmt.Sprintf("There's no category with the id %q.", id))
+		return nil
+	}
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Couldn't read category %s", id),
+			fmt.Sprintf("error: %v", err),
+		)
+		return nil
+	}
+	return category
+}
+
+// categoryByName lists the categories, as the marketplace can't look them up by name
+func (d *categoryDataSource) categoryByName(ctx context.Context, name string, diags *diag.Diagnostics) *sellerapi.Category {
+	categories, err := d.client.ListCategories(ctx)
+	if err != nil {
+		diags.AddError(
+			"Couldn't list categories",
+			fmt.Sprintf("error: %v", err),
+		)
+		return nil
+	}
+
+	var matching []sellerapi.Category
+	for _, category := range categories {
+		if category.Name == name {
+			matching = append(matching, category)
+		}
+	}
+
+	switch len(matching) {
+	case 0:
+		names := make([]string, 0, len(categories))
+		for _, category := range categories {
+			names = append(names, category.Name)
+		}
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Category not found",
+			fmt.Sprintf("There's no category named %q, the available categories are: %s.", name, strings.Join(names, ", ")),
+		)
+		return nil
+	case 1:
+		return &matching[0]
+	}
+
+	ids := make([]string, 0, len(matching))
+	for _, category := range matching {
+		ids = append(ids, category.Id)
+	}
+	diags.AddAttributeError(
+		path.Root("name"),
+		"Multiple categories found",
+		fmt.Sprintf("%d categories are named %q (%s), look the category up by its id instead.", len(matching), name, strings.Join(ids, ", ")),
+	)
+	return nil
+}
@@ -0,0 +1,133 @@
+package datasource_clusters
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+)
+
+var _ datasource.DataSource = (*clusterDataSource)(nil)
+
+// NewClusterDataSource looks up a single CCE cluster of a project by its name
+func NewClusterDataSource() datasource.DataSource {
+	return &clusterDataSource{}
+}
+
+type clusterDataSource struct {
+	client *sellerapi.Client
+}
+
+type clusterDataSourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	ProjectId types.String `tfsdk:"project_id"`
+}
+
+func (d *clusterDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster"
+}
+
+func (d *clusterDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A single CCE cluster of an OTC project, looked up by its name. " +
+			"Before this release, this data source listed all clusters, which is now `otc-marketplace_clusters`. " +
+			"See the CHANGELOG for upgrading.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Unique id for this cluster",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Exact name of the cluster",
+			},
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the Open Telekom Cloud project",
+			},
+		},
+	}
+}
+
+func (d *clusterDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	clientPTR, ok := req.ProviderData.(*sellerapi.Client)
+	if !ok || clientPTR == nil {
+		resp.Diagnostics.AddError(
+			"Provider Configuration Error",
+			"The provider was not configured correctly, or the API client is missing.",
+		)
+		return
+	}
+	d.client = clientPTR
+}
+
+// Read lists the clusters of the project, as the marketplace can't look them up by name
+func (d *clusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data clusterDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+	name := data.Name.ValueString()
+	clusters, err := d.client.ListClusters(ctx, projectId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Couldn't list the clusters of project %s", projectId),
+			fmt.Sprintf("error: %v", err),
+		)
+		return
+	}
+
+	var matching []sellerapi.Cluster
+	for _, cluster := range clusters {
+		if cluster.Name == name {
+			matching = append(matching, cluster)
+		}
+	}
+
+	switch len(matching) {
+	case 0:
+		names := make([]string, 0, len(clusters))
+		for _, cluster := range clusters {
+			names = append(names, cluster.Name)
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Cluster not found",
+			fmt.Sprintf("Project %s has no cluster named %q, its clusters are: %s.", projectId, name, strings.Join(names, ", ")),
+		)
+		return
+	case 1:
+	default:
+		ids := make([]string, 0, len(matching))
+		for _, cluster := range matching {
+			ids = append(ids, cluster.Id)
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Multiple clusters found",
+			fmt.Sprintf("%d clusters of project %s are named %q (%s).", len(matching), projectId, name, strings.Join(ids, ", ")),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(matching[0].Id)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -6,12 +6,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"net/http"
-	"terraform-provider-otc-marketplace/internal/util"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
 )
 
-const namespaceServicePath = "/namespaces"
-
 var _ datasource.DataSource = (*namespaceDataSource)(nil)
 
 func NewNamespaceDataSource() datasource.DataSource {
@@ -19,13 +16,7 @@ func NewNamespaceDataSource() datasource.DataSource {
 }
 
 type namespaceDataSource struct {
-	client *util.MarketplaceAPIClient
-}
-
-type namespaceDataSourceNativeModel struct {
-	Name      string `json:"name,omitempty" tfsdk:"name"`
-	ClusterId string `json:"cluster_id,omitempty" tfsdk:"cluster_id"`
-	ProjectId string `json:"project_id,omitempty" tfsdk:"project_id"`
+	client *sellerapi.Client
 }
 
 func (d *namespaceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -43,7 +34,7 @@ func (d *namespaceDataSource) Configure(ctx context.Context, req datasource.Conf
 		return
 	}
 
-	clientPTR, ok := req.ProviderData.(*util.MarketplaceAPIClient)
+	clientPTR, ok := req.ProviderData.(*sellerapi.Client)
 	if !ok || clientPTR == nil {
 		resp.Diagnostics.AddError(
 			"Provider Configuration Error",
@@ -60,18 +51,17 @@ func (d *namespaceDataSource) Read(ctx context.Context, req datasource.ReadReque
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
-	url := fmt.Sprintf("%s?project_id=%s&cluster_id=%s", namespaceServicePath, data.ProjectId.ValueString(), data.ClusterId.ValueString())
-	newDataNativeArrPTR, err := util.MakeMarketplaceRequest[[]namespaceDataSourceNativeModel](ctx, http.MethodGet, url, nil, d.client)
+	namespaces, err := d.client.ListNamespaces(ctx, data.ProjectId.ValueString(), data.ClusterId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %v", http.MethodGet, url, nil),
+			fmt.Sprintf("Couldn't list the namespaces of cluster %s", data.ClusterId.ValueString()),
 			fmt.Sprintf("error: %v", err),
 		)
 		return
 	}
 
 	var newData []attr.Value
-	for _, nativeNamespaces := range *newDataNativeArrPTR {
+	for _, nativeNamespaces := range namespaces {
 		namespaceObj, diags := NewNamespacesValue(NamespacesValue{}.AttributeTypes(ctx), map[string]attr.Value{
 			"name":       types.StringValue(nativeNamespaces.Name),
 			"project_id": types.StringValue(nativeNamespaces.ProjectId),
@@ -0,0 +1,244 @@
+package datasource_product_revisions
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"slices"
+	"strings"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+)
+
+var _ datasource.DataSource = (*productRevisionDataSource)(nil)
+
+// NewProductRevisionDataSource selects a single revision of a product, e.g. the newest approved one
+func NewProductRevisionDataSource() datasource.DataSource {
+	return &productRevisionDataSource{}
+}
+
+type productRevisionDataSource struct {
+	client *sellerapi.Client
+}
+
+type productRevisionDataSourceModel struct {
+	AdminSuggestion                         types.String `tfsdk:"admin_suggestion"`
+	Byol                                    ByolValue    `tfsdk:"byol"`
+	Categories                              types.List   `tfsdk:"categories"`
+	ContractualDocuments                    types.List   `tfsdk:"contractual_documents"`
+	ContractualDocumentsInfo                types.List   `tfsdk:"contractual_documents_info"`
+	Description                             types.String `tfsdk:"description"`
+	DescriptionShort                        types.String `tfsdk:"description_short"`
+	Eula                                    types.String `tfsdk:"eula"`
+	Guidance                                types.String `tfsdk:"guidance"`
+	HelmExternal                            types.String `tfsdk:"helm_external"`
+	Icon                                    types.String `tfsdk:"icon"`
+	Id                                      types.String `tfsdk:"id"`
+	LicenseFee                              types.String `tfsdk:"license_fee"`
+	LicenseInfo                             types.String `tfsdk:"license_info"`
+	MostRecent                              types.Bool   `tfsdk:"most_recent"`
+	Number                                  types.Int64  `tfsdk:"number"`
+	PostDeploymentInfo                      types.String `tfsdk:"post_deployment_info"`
+	PreDeploymentInfo                       types.String `tfsdk:"pre_deployment_info"`
+	PricingInfo                             types.String `tfsdk:"pricing_info"`
+	ProductId                               types.String `tfsdk:"product_id"`
+	ProductRevisionApplicationConfiguration types.List   `tfsdk:"product_revision_application_configuration"`
+	ProposedReleaseDate                     types.String `tfsdk:"proposed_release_date"`
+	ScheduledReleaseDate                    types.String `tfsdk:"scheduled_release_date"`
+	ScheduledReleaseUntilDate               types.String `tfsdk:"scheduled_release_until_date"`
+	State                                   types.String `tfsdk:"state"`
+	UsedSoftware                            types.List   `tfsdk:"used_software"`
+	Version                                 types.String `tfsdk:"version"`
+}
+
+func (d *productRevisionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_product_revision"
+}
+
+// Schema reuses the attributes of a revision in the generated product revisions schema, the selectors can be set
+func (d *productRevisionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	revisions, ok := ProductRevisionsDataSourceSchema(ctx).Attributes["product_revisions"].(schema.SetNestedAttribute)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected product revisions schema", "product_revisions is not a set of nested attributes")
+		return
+	}
+
+	attributes := map[string]schema.Attribute{}
+	for name, attribute := range revisions.NestedObject.Attributes {
+		attributes[name] = attribute
+	}
+	attributes["product_id"] = schema.StringAttribute{
+		Required:    true,
+		Description: "NanoID of the product to select a revision of",
+	}
+	attributes["state"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Only select a revision in this state, e.g. `approved`",
+		Validators: []validator.String{stringvalidator.OneOf(
+			string(sellerapi.RevisionStateDraft),
+			string(sellerapi.RevisionStateReadyForReview),
+			string(sellerapi.RevisionStateRejected),
+			string(sellerapi.RevisionStateApproved),
+		)},
+	}
+	attributes["version"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Only select the revision with exactly this version",
+	}
+	attributes["number"] = schema.Int64Attribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Only select the revision with this number",
+	}
+	attributes["most_recent"] = schema.BoolAttribute{
+		Optional: true,
+		Description: "If more than one revision matches, select the newest instead of failing. Revisions with a " +
+			"semantic version rank above those without one and are ordered by it, the others by their number",
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "A single revision of a product, selected by its state, version or number. " +
+			"Before this release, this data source listed all product revisions, which is now " +
+			"`otc-marketplace_product_revisions`. See the CHANGELOG for upgrading.",
+		Attributes: attributes,
+	}
+}
+
+func (d *productRevisionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	clientPTR, ok := req.ProviderData.(*sellerapi.Client)
+	if !ok || clientPTR == nil {
+		resp.Diagnostics.AddError(
+			"Provider Configuration Error",
+			"The provider was not configured correctly, or the API client is missing.",
+		)
+		return
+	}
+	d.client = clientPTR
+}
+
+func (d *productRevisionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data productRevisionDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	selector := revisionSelector{productId: data.ProductId.ValueString()}
+	if !data.State.IsNull() {
+		state := sellerapi.RevisionState(data.State.ValueString())
+		selector.state = &state
+	}
+	if !data.Version.IsNull() {
+		selector.version = data.Version.ValueStringPointer()
+	}
+	if !data.Number.IsNull() {
+		selector.number = data.Number.ValueInt64Pointer()
+	}
+
+	revisions, err := d.client.ListRevisions(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Couldn't list product revisions",
+			fmt.Sprintf("error: %v", err),
+		)
+		return
+	}
+
+	var matching []sellerapi.ProductRevision
+	for _, revision := range revisions {
+		if selector.matches(revision) {
+			matching = append(matching, revision)
+		}
+	}
+
+	if len(matching) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("product_id"),
+			"Product revision not found",
+			fmt.Sprintf("No revision of product %s matches the selectors.", selector.productId),
+		)
+		return
+	}
+	if len(matching) > 1 && !data.MostRecent.ValueBool() {
+		numbers := make([]string, 0, len(matching))
+		for _, revision := range matching {
+			numbers = append(numbers, fmt.Sprintf("%d (%s)", revision.Number, revision.Version))
+		}
+		resp.Diagnostics.AddError(
+			"Multiple product revisions found",
+			fmt.Sprintf("%d revisions of product %s match the selectors: %s. Narrow them down or set most_recent = true.",
+				len(matching), selector.productId, strings.Join(numbers, ", ")),
+		)
+		return
+	}
+
+	revision := slices.MaxFunc(matching, compareRevisions)
+	resp.Diagnostics.Append(data.set(ctx, revision)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// set copies the selected revision into the model, the selectors are kept as they are
+func (m *productRevisionDataSourceModel) set(ctx context.Context, revision sellerapi.ProductRevision) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	configsList, configDiags := newConfigurationList(ctx, revision.Configuration)
+	diags.Append(configDiags...)
+	categoryList, categoryDiags := newCategoryList(revision.Categories)
+	diags.Append(categoryDiags...)
+	softList, softDiags := newUsedSoftwareList(ctx, revision.UsedSoftware)
+	diags.Append(softDiags...)
+	docInfoList, docDiags := newContractualDocumentsInfoList(ctx, revision.ContractualDocumentsInfo)
+	diags.Append(docDiags...)
+	byol, byolDiags := newByolValue(ctx, revision.Byol)
+	diags.Append(byolDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	m.AdminSuggestion = types.StringValue(revision.AdminSuggestion)
+	m.Byol = byol
+	m.Categories = categoryList
+	m.ContractualDocuments = types.ListNull(ContractualDocumentsValue{}.Type(ctx)) // Backend converts these to contractual_documents_info
+	m.ContractualDocumentsInfo = docInfoList
+	m.Description = types.StringValue(revision.Description)
+	m.DescriptionShort = types.StringValue(revision.DescriptionShort)
+	m.Eula = types.StringValue(revision.Eula)
+	m.Guidance = types.StringValue(revision.Guidance)
+	m.HelmExternal = types.StringValue(revision.HelmExternal)
+	m.Icon = types.StringValue(revision.Icon)
+	m.Id = types.StringValue(revision.Id)
+	m.LicenseFee = types.StringValue(revision.LicenseFee)
+	m.LicenseInfo = types.StringValue(revision.LicenseInfo)
+	m.Number = types.Int64Value(revision.Number)
+	m.PostDeploymentInfo = types.StringValue(revision.PostDeploymentInfo)
+	m.PreDeploymentInfo = types.StringValue(revision.PreDeploymentInfo)
+	m.PricingInfo = types.StringValue(revision.PricingInfo)
+	m.ProductRevisionApplicationConfiguration = configsList
+	m.ProposedReleaseDate = types.StringValue(revision.ProposedReleaseDate)
+	m.ScheduledReleaseDate = types.StringValue(revision.ScheduledReleaseDate)
+	m.ScheduledReleaseUntilDate = types.StringValue(revision.ScheduledReleaseUntilDate)
+	m.State = types.StringValue(string(revision.State))
+	m.UsedSoftware = softList
+	m.Version = types.StringValue(revision.Version)
+	return diags
+}
@@ -4,88 +4,37 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"net/http"
-	"terraform-provider-otc-marketplace/internal/resource_product_revision"
-	"terraform-provider-otc-marketplace/internal/util"
-
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
 )
 
-const productRevisionServicePath = "/product-revisions"
-
-var _ datasource.DataSource = (*productRevisionDataSource)(nil)
-
-func NewProductRevisionDataSource() datasource.DataSource {
-	return &productRevisionDataSource{}
-}
-
-type productRevisionDataSource struct {
-	client *util.MarketplaceAPIClient
-}
-
-type productRevisionNativeModel struct {
-	AdminSuggestion           string                                                               `json:"admin_suggestion,omitempty" tfsdk:"admin_suggestion"`
-	Eula                      string                                                               `json:"eula,omitempty" tfsdk:"eula"`
-	Byol                      byolNativeModel                                                      `json:"byol,omitempty" tfsdk:"byol"`
-	Categories                []string                                                             `json:"categories,omitempty" tfsdk:"categories"`
-	Configuration             []resource_product_revision.ProductRevisionResourceConfigNativeModel `json:"configuration,omitempty" tfsdk:"configuration"` // TODO naming
-	ContractualDocumentsInfo  []contractualDocsInfoNativeModel                                     `json:"contractual_documents_info,omitempty" tfsdk:"contractual_documents_info"`
-	Description               string                                                               `json:"description,omitempty" tfsdk:"description"`
-	DescriptionShort          string                                                               `json:"description_short,omitempty" tfsdk:"description_short"`
-	Guidance                  string                                                               `json:"guidance,omitempty" tfsdk:"guidance"`
-	HelmExternal              string                                                               `json:"helm_external,omitempty" tfsdk:"helm_external"`
-	Icon                      string                                                               `json:"icon,omitempty" tfsdk:"icon"`
-	Id                        string                                                               `json:"id,omitempty" tfsdk:"id"`
-	LicenseFee                string                                                               `json:"license_fee,omitempty" tfsdk:"license_fee"`
-	LicenseInfo               string                                                               `json:"license_info,omitempty" tfsdk:"license_info"`
-	Number                    int64                                                                `json:"number,omitempty" tfsdk:"number"`
-	PostDeploymentInfo        string                                                               `json:"post_deployment_info,omitempty" tfsdk:"post_deployment_info"`
-	PreDeploymentInfo         string                                                               `json:"pre_deployment_info,omitempty" tfsdk:"pre_deployment_info"`
-	PricingInfo               string                                                               `json:"pricing_info,omitempty" tfsdk:"pricing_info"`
-	ProductId                 string                                                               `json:"product_id,omitempty" tfsdk:"product_id"`
-	ProposedReleaseDate       string                                                               `json:"proposed_release_date,omitempty" tfsdk:"proposed_release_date"`
-	ScheduledReleaseDate      string                                                               `json:"scheduled_release_date,omitempty" tfsdk:"scheduled_release_date"`
-	ScheduledReleaseUntilDate string                                                               `json:"scheduled_release_until_date,omitempty" tfsdk:"scheduled_release_until_date"`
-	State                     string                                                               `json:"state,omitempty" tfsdk:"state"` // TODO - enum?
-	UsedSoftware              []productRevisionUsedNativeSoftwareModel                             `json:"used_software,omitempty" tfsdk:"used_software"`
-	Version                   string                                                               `json:"version,omitempty" tfsdk:"version"`
-}
-
-type byolNativeModel struct {
-	ActivationUrl    string `json:"activation_url,omitempty" tfsdk:"activation_url"`
-	FilenameInSecret string `json:"file_name_in_secret,omitempty" tfsdk:"file_name_in_secret"`
-	SecretName       string `json:"secret_name,omitempty" tfsdk:"secret_name"`
-	WebshopUrl       string `json:"webshop_url,omitempty" tfsdk:"webshop_url"`
-}
+var _ datasource.DataSource = (*productRevisionsDataSource)(nil)
 
-type productRevisionUsedNativeSoftwareModel struct {
-	LicenseName string `json:"license_name,omitempty" tfsdk:"license_name"`
-	LicenseUrl  string `json:"license_url,omitempty" tfsdk:"license_url"`
-	Name        string `json:"name,omitempty" tfsdk:"name"`
+func NewProductRevisionsDataSource() datasource.DataSource {
+	return &productRevisionsDataSource{}
 }
 
-type contractualDocsInfoNativeModel struct {
-	Filename string `json:"file_name,omitempty" tfsdk:"file_name"`
-	Url      string `json:"url,omitempty" tfsdk:"url"`
+type productRevisionsDataSource struct {
+	client *sellerapi.Client
 }
 
-func (d *productRevisionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_product_revision"
+func (d *productRevisionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_product_revisions"
 }
-func (d *productRevisionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+func (d *productRevisionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = ProductRevisionsDataSourceSchema(ctx)
 }
 
-func (d *productRevisionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+func (d *productRevisionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
 		// to handle this gracefully. It will eventually be called with a configured provider.
 		return
 	}
 
-	clientPTR, ok := req.ProviderData.(*util.MarketplaceAPIClient)
+	clientPTR, ok := req.ProviderData.(*sellerapi.Client)
 	if !ok || clientPTR == nil {
 		resp.Diagnostics.AddError(
 			"Provider Configuration Error",
@@ -96,184 +45,222 @@ func (d *productRevisionDataSource) Configure(ctx context.Context, req datasourc
 	d.client = clientPTR
 }
 
-func (d *productRevisionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+func (d *productRevisionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data ProductRevisionsModel
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	newDataNativeArrPTR, err := util.MakeMarketplaceRequest[[]productRevisionNativeModel](ctx, http.MethodGet, productRevisionServicePath, nil, d.client)
+	revisions, err := d.client.ListRevisions(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %v", http.MethodGet, productRevisionServicePath, nil),
+			"Couldn't list product revisions",
 			fmt.Sprintf("error: %v", err),
 		)
 		return
 	}
 
 	var newData []attr.Value
-	var tempNewConfigs []attr.Value
-	var tempNewCategories []attr.Value
-	var tempNewUsedSoftware []attr.Value
-	var tempContDocsInfo []attr.Value
+	for _, nativePRs := range revisions {
+		productObj, diags := newProductRevisionsValue(ctx, nativePRs)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 
-	for _, nativePRs := range *newDataNativeArrPTR {
-		for _, config := range nativePRs.Configuration {
-			var tempValidation []attr.Value
-			var tempValues []attr.Value
-			var diags diag.Diagnostics
-
-			for _, val := range config.Validation {
-				valObj, valDiags := NewValidationValue(ValidationValue{}.AttributeTypes(ctx), map[string]attr.Value{
-					"message": types.StringValue(val.Message),
-					"pattern": types.StringValue(val.Pattern),
-				})
-				diags.Append(valDiags...)
-				if diags.HasError() {
-					return
-				}
-				tempValidation = append(tempValidation, valObj)
-			}
-
-			validationList, diags := types.ListValue(ValidationValue{}.Type(ctx), tempValidation)
-			if diags.HasError() {
-				return
-			}
-
-			for _, val := range config.Values {
-				valObj, valDiags := NewValuesValue(ValuesValue{}.AttributeTypes(ctx), map[string]attr.Value{
-					"label": types.StringValue(val.Label),
-					"value": types.StringValue(val.Value),
-				})
-				diags.Append(valDiags...)
-				if diags.HasError() {
-					return
-				}
-				tempValues = append(tempValues, valObj)
-			}
-
-			valuesList, diags := types.ListValue(ValuesValue{}.Type(ctx), tempValues)
-			if diags.HasError() {
-				return
-			}
-
-			confObj, diags := NewProductRevisionApplicationConfigurationValue(ProductRevisionApplicationConfigurationValue{}.AttributeTypes(ctx), map[string]attr.Value{
-				"confidential":  types.BoolValue(config.Confidential),
-				"default_value": types.StringValue(config.DefaultValue),
-				"hidden":        types.BoolValue(config.Hidden),
-				"hint":          types.StringValue(config.Hint),
-				"input_type":    types.StringValue(config.InputType),
-				"key":           types.StringValue(config.Key),
-				"label":         types.StringValue(config.Label),
-				"multiple":      types.BoolValue(config.Multiple),
-				"required":      types.BoolValue(config.Required),
-				"tooltip":       types.StringValue(config.Tooltip),
-				"validation":    validationList,
-				"values":        valuesList,
-			})
-			if diags.HasError() {
-				return
-			}
+		newData = append(newData, productObj)
+	}
 
-			tempNewConfigs = append(tempNewConfigs, confObj)
-		}
+	productRevisionsSet, diags := types.SetValue(ProductRevisionsValue{}.Type(ctx), newData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		configsList, diags := types.ListValue(ProductRevisionApplicationConfigurationValue{}.Type(ctx), tempNewConfigs)
-		if diags.HasError() {
-			return
-		}
+	data = ProductRevisionsModel{ProductRevisions: productRevisionsSet}
 
-		for _, category := range nativePRs.Categories {
-			tempNewCategories = append(tempNewCategories, types.StringValue(category))
-		}
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func newProductRevisionsValue(ctx context.Context, revision sellerapi.ProductRevision) (ProductRevisionsValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	configsList, configDiags := newConfigurationList(ctx, revision.Configuration)
+	diags.Append(configDiags...)
+	categoryList, categoryDiags := newCategoryList(revision.Categories)
+	diags.Append(categoryDiags...)
+	softList, softDiags := newUsedSoftwareList(ctx, revision.UsedSoftware)
+	diags.Append(softDiags...)
+	docInfoList, docDiags := newContractualDocumentsInfoList(ctx, revision.ContractualDocumentsInfo)
+	diags.Append(docDiags...)
+	byol, byolDiags := newByolValue(ctx, revision.Byol)
+	diags.Append(byolDiags...)
+	if diags.HasError() {
+		return ProductRevisionsValue{}, diags
+	}
 
-		categoryList, diags := types.ListValue(types.StringType, tempNewCategories)
+	byolAsObj, objDiags := byol.ToObjectValue(ctx)
+	diags.Append(objDiags...)
+	if diags.HasError() {
+		return ProductRevisionsValue{}, diags
+	}
+
+	productObj, productDiags := NewProductRevisionsValue(ProductRevisionsValue{}.AttributeTypes(ctx), map[string]attr.Value{
+		"admin_suggestion":           types.StringValue(revision.AdminSuggestion),
+		"byol":                       byolAsObj,
+		"categories":                 categoryList,
+		"contractual_documents":      types.ListNull(ContractualDocumentsValue{}.Type(ctx)), // Backend converts these to contractual_documents_info
+		"contractual_documents_info": docInfoList,
+		"description":                types.StringValue(revision.Description),
+		"description_short":          types.StringValue(revision.DescriptionShort),
+		"eula":                       types.StringValue(revision.Eula),
+		"guidance":                   types.StringValue(revision.Guidance),
+		"helm_external":              types.StringValue(revision.HelmExternal),
+		"icon":                       types.StringValue(revision.Icon),
+		"id":                         types.StringValue(revision.Id),
+		"license_fee":                types.StringValue(revision.LicenseFee),
+		"license_info":               types.StringValue(revision.LicenseInfo),
+		"number":                     types.Int64Value(revision.Number),
+		"post_deployment_info":       types.StringValue(revision.PostDeploymentInfo),
+		"pre_deployment_info":        types.StringValue(revision.PreDeploymentInfo),
+		"pricing_info":               types.StringValue(revision.PricingInfo),
+		"product_id":                 types.StringValue(revision.ProductId),
+		"product_revision_application_configuration": configsList,
+		"proposed_release_date":                      types.StringValue(revision.ProposedReleaseDate),
+		"scheduled_release_date":                     types.StringValue(revision.ScheduledReleaseDate),
+		"scheduled_release_until_date":               types.StringValue(revision.ScheduledReleaseUntilDate),
+		"state":                                      types.StringValue(string(revision.State)),
+		"used_software":                              softList,
+		"version":                                    types.StringValue(revision.Version),
+	})
+	diags.Append(productDiags...)
+	return productObj, diags
+}
+
+func newConfigurationList(ctx context.Context, configuration []sellerapi.ConfigurationTemplate) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var tempNewConfigs []attr.Value
 
-		for _, usedSoftwareSingle := range nativePRs.UsedSoftware {
-			softObj, softDiags := NewUsedSoftwareValue(UsedSoftwareValue{}.AttributeTypes(ctx), map[string]attr.Value{
-				"license_name": types.StringValue(usedSoftwareSingle.LicenseName),
-				"license_url":  types.StringValue(usedSoftwareSingle.LicenseUrl),
-				"name":         types.StringValue(usedSoftwareSingle.Name),
+	for _, config := range configuration {
+		var tempValidation []attr.Value
+		for _, val := range config.Validation {
+			valObj, valDiags := NewValidationValue(ValidationValue{}.AttributeTypes(ctx), map[string]attr.Value{
+				"message": types.StringValue(val.Message),
+				"pattern": types.StringValue(val.Pattern),
 			})
-			diags.Append(softDiags...)
-			if diags.HasError() {
-				return
-			}
-			tempNewUsedSoftware = append(tempNewUsedSoftware, softObj)
+			diags.Append(valDiags...)
+			tempValidation = append(tempValidation, valObj)
 		}
 
-		softList, diags := types.ListValue(UsedSoftwareValue{}.Type(ctx), tempNewUsedSoftware)
-
-		byol, diags := NewByolValue(ByolValue{}.AttributeTypes(ctx), map[string]attr.Value{
-			"activation_url":      types.StringValue(nativePRs.Byol.ActivationUrl),
-			"file_name_in_secret": types.StringValue(nativePRs.Byol.FilenameInSecret),
-			"secret_name":         types.StringValue(nativePRs.Byol.SecretName),
-			"webshop_url":         types.StringValue(nativePRs.Byol.WebshopUrl),
-		})
+		validationList, listDiags := types.ListValue(ValidationValue{}.Type(ctx), tempValidation)
+		diags.Append(listDiags...)
 
-		for _, docInfo := range nativePRs.ContractualDocumentsInfo {
-			docObj, docDiags := NewContractualDocumentsInfoValue(ContractualDocumentsInfoValue{}.AttributeTypes(ctx), map[string]attr.Value{
-				"file_name": types.StringValue(docInfo.Filename),
-				"url":       types.StringValue(docInfo.Url),
+		var tempValues []attr.Value
+		for _, val := range config.Values {
+			valObj, valDiags := NewValuesValue(ValuesValue{}.AttributeTypes(ctx), map[string]attr.Value{
+				"label": types.StringValue(val.Label),
+				"value": types.StringValue(val.Value),
 			})
-			diags.Append(docDiags...)
-			if diags.HasError() {
-				return
-			}
-			tempContDocsInfo = append(tempContDocsInfo, docObj)
+			diags.Append(valDiags...)
+			tempValues = append(tempValues, valObj)
 		}
 
-		docInfoList, diags := types.ListValue(ContractualDocumentsInfoValue{}.Type(ctx), tempContDocsInfo)
+		valuesList, listDiags := types.ListValue(ValuesValue{}.Type(ctx), tempValues)
+		diags.Append(listDiags...)
 		if diags.HasError() {
-			return
+			return types.ListNull(ProductRevisionApplicationConfigurationValue{}.Type(ctx)), diags
 		}
 
-		productObj, diags := NewProductRevisionsValue(ProductRevisionsValue{}.AttributeTypes(ctx), map[string]attr.Value{
-			"admin_suggestion":           types.StringValue(nativePRs.AdminSuggestion),
-			"byol":                       byol,
-			"categories":                 categoryList,
-			"contractual_documents":      types.ListNull(ContractualDocumentsValue{}.Type(ctx)), // Backend converts these to contractual_documents_info
-			"contractual_documents_info": docInfoList,
-			"description":                types.StringValue(nativePRs.Description),
-			"description_short":          types.StringValue(nativePRs.Description),
-			"eula":                       types.StringValue(nativePRs.Eula),
-			"guidance":                   types.StringValue(nativePRs.Guidance),
-			"helm_external":              types.StringValue(nativePRs.HelmExternal),
-			"icon":                       types.StringValue(nativePRs.Icon),
-			"id":                         types.StringValue(nativePRs.Id),
-			"license_fee":                types.StringValue(nativePRs.LicenseFee),
-			"license_info":               types.StringValue(nativePRs.LicenseInfo),
-			"number":                     types.Int64Value(nativePRs.Number),
-			"post_deployment_info":       types.StringValue(nativePRs.PostDeploymentInfo),
-			"pre_deployment_info":        types.StringValue(nativePRs.PreDeploymentInfo),
-			"pricing_info":               types.StringValue(nativePRs.PricingInfo),
-			"product_id":                 types.StringValue(nativePRs.ProductId),
-			"product_revision_application_configuration": configsList,
-			"proposed_release_date":                      types.StringValue(nativePRs.ProposedReleaseDate),
-			"scheduled_release_date":                     types.StringValue(nativePRs.ScheduledReleaseDate),
-			"scheduled_release_until_date":               types.StringValue(nativePRs.ScheduledReleaseUntilDate),
-			"state":                                      types.StringValue(nativePRs.State),
-			"used_software":                              softList,
-			"version":                                    types.StringValue(nativePRs.Version),
+		confObj, confDiags := NewProductRevisionApplicationConfigurationValue(ProductRevisionApplicationConfigurationValue{}.AttributeTypes(ctx), map[string]attr.Value{
+			"confidential":  types.BoolValue(config.Confidential),
+			"default_value": types.StringValue(string(config.DefaultValue)),
+			"hidden":        types.BoolValue(config.Hidden),
+			"hint":          types.StringValue(config.Hint),
+			"input_type":    types.StringValue(string(config.InputType)),
+			"key":           types.StringValue(config.Key),
+			"label":         types.StringValue(config.Label),
+			"multiple":      types.BoolValue(config.Multiple),
+			"required":      types.BoolValue(config.Required),
+			"tooltip":       types.StringValue(config.Tooltip),
+			"validation":    validationList,
+			"values":        valuesList,
 		})
-		if diags.HasError() {
-			return
-		}
+		diags.Append(confDiags...)
+		tempNewConfigs = append(tempNewConfigs, confObj)
+	}
+	if diags.HasError() {
+		return types.ListNull(ProductRevisionApplicationConfigurationValue{}.Type(ctx)), diags
+	}
 
-		newData = append(newData, productObj)
+	configsList, listDiags := types.ListValue(ProductRevisionApplicationConfigurationValue{}.Type(ctx), tempNewConfigs)
+	diags.Append(listDiags...)
+	return configsList, diags
+}
+
+func newCategoryList(categories []string) (types.List, diag.Diagnostics) {
+	var tempNewCategories []attr.Value
+	for _, category := range categories {
+		tempNewCategories = append(tempNewCategories, types.StringValue(category))
 	}
+	return types.ListValue(types.StringType, tempNewCategories)
+}
 
-	productRevisionsSet, diags := types.SetValue(ProductRevisionsValue{}.Type(ctx), newData)
+func newUsedSoftwareList(ctx context.Context, usedSoftware []sellerapi.UsedSoftware) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var tempNewUsedSoftware []attr.Value
+
+	for _, usedSoftwareSingle := range usedSoftware {
+		softObj, softDiags := NewUsedSoftwareValue(UsedSoftwareValue{}.AttributeTypes(ctx), map[string]attr.Value{
+			"license_name": types.StringValue(usedSoftwareSingle.LicenseName),
+			"license_url":  types.StringValue(usedSoftwareSingle.LicenseUrl),
+			"name":         types.StringValue(usedSoftwareSingle.Name),
+		})
+		diags.Append(softDiags...)
+		tempNewUsedSoftware = append(tempNewUsedSoftware, softObj)
+	}
 	if diags.HasError() {
-		return
+		return types.ListNull(UsedSoftwareValue{}.Type(ctx)), diags
 	}
 
-	data = ProductRevisionsModel{ProductRevisions: productRevisionsSet}
+	softList, listDiags := types.ListValue(UsedSoftwareValue{}.Type(ctx), tempNewUsedSoftware)
+	diags.Append(listDiags...)
+	return softList, diags
+}
 
-	if resp.Diagnostics.HasError() {
-		return
+func newContractualDocumentsInfoList(ctx context.Context, docsInfo []sellerapi.ContractualDocumentInfo) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var tempContDocsInfo []attr.Value
+
+	for _, docInfo := range docsInfo {
+		docObj, docDiags := NewContractualDocumentsInfoValue(ContractualDocumentsInfoValue{}.AttributeTypes(ctx), map[string]attr.Value{
+			"file_name": types.StringValue(docInfo.FileName),
+			"url":       types.StringValue(docInfo.Url),
+		})
+		diags.Append(docDiags...)
+		tempContDocsInfo = append(tempContDocsInfo, docObj)
+	}
+	if diags.HasError() {
+		return types.ListNull(ContractualDocumentsInfoValue{}.Type(ctx)), diags
 	}
 
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	docInfoList, listDiags := types.ListValue(ContractualDocumentsInfoValue{}.Type(ctx), tempContDocsInfo)
+	diags.Append(listDiags...)
+	return docInfoList, diags
+}
+
+func newByolValue(ctx context.Context, byol *sellerapi.Byol) (ByolValue, diag.Diagnostics) {
+	nativeByol := sellerapi.Byol{}
+	if byol != nil {
+		nativeByol = *byol
+	}
+	return NewByolValue(ByolValue{}.AttributeTypes(ctx), map[string]attr.Value{
+		"activation_url":      types.StringValue(nativeByol.ActivationUrl),
+		"file_name_in_secret": types.StringValue(nativeByol.FileNameInSecret),
+		"secret_name":         types.StringValue(nativeByol.SecretName),
+		"webshop_url":         types.StringValue(nativeByol.WebshopUrl),
+	})
 }
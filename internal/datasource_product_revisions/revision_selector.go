@@ -0,0 +1,112 @@
+package datasource_product_revisions
+
+import (
+	"cmp"
+	"regexp"
+	"strconv"
+	"strings"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+)
+
+// revisionSelector matches the revisions of a product against the selectors that are set
+type revisionSelector struct {
+	productId string
+	state     *sellerapi.RevisionState
+	version   *string
+	number    *int64
+}
+
+func (s revisionSelector) matches(revision sellerapi.ProductRevision) bool {
+	return revision.ProductId == s.productId &&
+		(s.state == nil || revision.State == *s.state) &&
+		(s.version == nil || revision.Version == *s.version) &&
+		(s.number == nil || revision.Number == *s.number)
+}
+
+// compareRevisions orders revisions with a semantic version above those without one, so the order stays consistent
+// if only some versions are semver. Revisions are then ordered by their semantic version, and by their number if
+// neither is semver or the versions are equal.
+func compareRevisions(a sellerapi.ProductRevision, b sellerapi.ProductRevision) int {
+	versionA, okA := parseSemver(a.Version)
+	versionB, okB := parseSemver(b.Version)
+	switch {
+	case okA && !okB:
+		return 1
+	case !okA && okB:
+		return -1
+	case okA && okB:
+		if c := versionA.compare(versionB); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(a.Number, b.Number)
+}
+
+// semverRegex follows https://semver.org, a leading `v` as used by many helm charts is allowed
+var semverRegex = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*)?$`)
+
+type semver struct {
+	core       [3]uint64
+	prerelease []string
+}
+
+func parseSemver(version string) (semver, bool) {
+	match := semverRegex.FindStringSubmatch(version)
+	if match == nil {
+		return semver{}, false
+	}
+
+	var v semver
+	for i := range v.core {
+		number, err := strconv.ParseUint(match[i+1], 10, 64)
+		if err != nil {
+			return semver{}, false
+		}
+		v.core[i] = number
+	}
+	if match[4] != "" {
+		v.prerelease = strings.Split(match[4], ".")
+	}
+	return v, true
+}
+
+// compare follows the precedence rules of semver, the build metadata is ignored
+func (v semver) compare(o semver) int {
+	for i := range v.core {
+		if c := cmp.Compare(v.core[i], o.core[i]); c != 0 {
+			return c
+		}
+	}
+
+	// A pre-release has a lower precedence than the release
+	switch {
+	case len(v.prerelease) == 0 && len(o.prerelease) == 0:
+		return 0
+	case len(v.prerelease) == 0:
+		return 1
+	case len(o.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.prerelease) && i < len(o.prerelease); i++ {
+		if c := comparePrereleaseIdentifier(v.prerelease[i], o.prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(v.prerelease), len(o.prerelease))
+}
+
+// comparePrereleaseIdentifier compares numeric identifiers numerically, they're lower than alphanumeric ones
+func comparePrereleaseIdentifier(a string, b string) int {
+	numberA, errA := strconv.ParseUint(a, 10, 64)
+	numberB, errB := strconv.ParseUint(b, 10, 64)
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Compare(numberA, numberB)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
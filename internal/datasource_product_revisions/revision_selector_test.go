@@ -0,0 +1,111 @@
+package datasource_product_revisions
+
+import (
+	"slices"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+	"testing"
+)
+
+func TestCompareRevisions(t *testing.T) {
+	tests := []struct {
+		name     string
+		versionA string
+		numberA  int64
+		versionB string
+		numberB  int64
+		// want is the sign of compareRevisions(a, b)
+		want int
+	}{
+		{name: "major", versionA: "2.0.0", numberA: 1, versionB: "1.9.9", numberB: 2, want: 1},
+		{name: "minor numerically", versionA: "1.10.0", numberA: 1, versionB: "1.9.0", numberB: 2, want: 1},
+		{name: "patch", versionA: "1.0.1", numberA: 1, versionB: "1.0.2", numberB: 2, want: -1},
+		{name: "pre-release before release", versionA: "1.0.0-rc.1", numberA: 2, versionB: "1.0.0", numberB: 1, want: -1},
+		{name: "release after pre-release", versionA: "1.0.0", numberA: 1, versionB: "1.0.0-alpha", numberB: 2, want: 1},
+		{name: "pre-release of the next version", versionA: "1.1.0-alpha", numberA: 1, versionB: "1.0.0", numberB: 2, want: 1},
+		{name: "numeric pre-release identifiers", versionA: "1.0.0-rc.10", numberA: 1, versionB: "1.0.0-rc.9", numberB: 2, want: 1},
+		{name: "numeric before alphanumeric", versionA: "1.0.0-1", numberA: 2, versionB: "1.0.0-alpha", numberB: 1, want: -1},
+		{name: "alphanumeric identifiers", versionA: "1.0.0-beta", numberA: 1, versionB: "1.0.0-alpha", numberB: 2, want: 1},
+		{name: "more identifiers", versionA: "1.0.0-alpha.1", numberA: 1, versionB: "1.0.0-alpha", numberB: 2, want: 1},
+		{name: "v prefix", versionA: "v1.2.0", numberA: 1, versionB: "1.10.0", numberB: 2, want: -1},
+		{name: "v prefix on both", versionA: "v2.0.0", numberA: 1, versionB: "v1.0.0", numberB: 2, want: 1},
+		{name: "tie with v prefix uses the number", versionA: "v1.0.0", numberA: 3, versionB: "1.0.0", numberB: 2, want: 1},
+		{name: "tie with build metadata uses the number", versionA: "1.0.0+build.1", numberA: 1, versionB: "1.0.0+build.2", numberB: 2, want: -1},
+		{name: "same version uses the number", versionA: "1.0.0", numberA: 5, versionB: "1.0.0", numberB: 4, want: 1},
+		{name: "non-semver uses the number", versionA: "latest", numberA: 2, versionB: "stable", numberB: 1, want: 1},
+		{name: "semver above non-semver", versionA: "1.0.0", numberA: 1, versionB: "2024-01", numberB: 2, want: 1},
+		{name: "non-semver below semver", versionA: "2024-01", numberA: 2, versionB: "1.0.0", numberB: 1, want: -1},
+		{name: "two part version isn't semver", versionA: "1.10", numberA: 1, versionB: "1.9", numberB: 2, want: -1},
+		{name: "leading zero isn't semver", versionA: "1.02.0", numberA: 2, versionB: "1.1.0", numberB: 1, want: -1},
+		{name: "empty version below semver", versionA: "", numberA: 2, versionB: "1.0.0", numberB: 1, want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := sellerapi.ProductRevision{Version: tt.versionA, Number: tt.numberA}
+			b := sellerapi.ProductRevision{Version: tt.versionB, Number: tt.numberB}
+
+			if got := sign(compareRevisions(a, b)); got != tt.want {
+				t.Errorf("compareRevisions(%s, %s): expected %d, got %d", tt.versionA, tt.versionB, tt.want, got)
+			}
+			if got := sign(compareRevisions(b, a)); got != -tt.want {
+				t.Errorf("compareRevisions(%s, %s): expected %d, got %d", tt.versionB, tt.versionA, -tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLatestRevision(t *testing.T) {
+	revisions := []sellerapi.ProductRevision{
+		{Id: "1", Number: 1, Version: "1.0.0"},
+		{Id: "2", Number: 2, Version: "1.10.0"},
+		{Id: "3", Number: 3, Version: "1.9.0"},
+		{Id: "4", Number: 4, Version: "2.0.0-rc.1"},
+		{Id: "5", Number: 5, Version: "1.10.0-rc.1"},
+	}
+
+	if latest := slices.MaxFunc(revisions, compareRevisions); latest.Id != "4" {
+		t.Errorf("expected revision 4, got %s", latest.Id)
+	}
+	if latest := slices.MaxFunc(revisions[:3], compareRevisions); latest.Id != "2" {
+		t.Errorf("expected revision 2, got %s", latest.Id)
+	}
+}
+
+func TestLatestRevisionMixedVersions(t *testing.T) {
+	// Falling back to the number for each pair with a non-semver version made this a cycle: 3 < 1 by version, but
+	// 1 < 2 and 2 < 3 by number
+	revisions := []sellerapi.ProductRevision{
+		{Id: "1", Number: 1, Version: "2.0.0"},
+		{Id: "2", Number: 2, Version: "latest"},
+		{Id: "3", Number: 3, Version: "1.0.0"},
+		{Id: "4", Number: 4, Version: "nightly"},
+	}
+
+	// The result mustn't depend on the order the marketplace lists the revisions in
+	for i := range revisions {
+		rotated := append(slices.Clone(revisions[i:]), revisions[:i]...)
+		if latest := slices.MaxFunc(rotated, compareRevisions); latest.Id != "1" {
+			t.Errorf("rotation %d: expected revision 1, got %s", i, latest.Id)
+		}
+
+		sorted := slices.Clone(rotated)
+		slices.SortFunc(sorted, compareRevisions)
+		var ids []string
+		for _, revision := range sorted {
+			ids = append(ids, revision.Id)
+		}
+		if want := []string{"2", "4", "3", "1"}; !slices.Equal(ids, want) {
+			t.Errorf("rotation %d: expected %v, got %v", i, want, ids)
+		}
+	}
+}
+
+func sign(c int) int {
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,197 @@
+package datasource_products
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+	"terraform-provider-otc-marketplace/internal/util"
+)
+
+var _ datasource.DataSource = (*productDataSource)(nil)
+var _ datasource.DataSourceWithConfigValidators = (*productDataSource)(nil)
+
+// NewProductDataSource looks up a single product by its id or name
+func NewProductDataSource() datasource.DataSource {
+	return &productDataSource{}
+}
+
+type productDataSource struct {
+	client *sellerapi.Client
+}
+
+type productDataSourceModel struct {
+	ActiveRevisionId types.String `tfsdk:"active_revision_id"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+	Eol              types.Bool   `tfsdk:"eol"`
+	EolDate          types.String `tfsdk:"eol_date"`
+	Id               types.String `tfsdk:"id"`
+	LicenseType      types.String `tfsdk:"license_type"`
+	Name             types.String `tfsdk:"name"`
+	Seller           SellerValue  `tfsdk:"seller"`
+	State            types.String `tfsdk:"state"`
+	Type             types.String `tfsdk:"type"`
+	Weight           types.Int64  `tfsdk:"weight"`
+}
+
+func (d *productDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_product"
+}
+
+// Schema reuses the attributes of a product in the generated products schema, only id and name can be set
+func (d *productDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	products, ok := ProductsDataSourceSchema(ctx).Attributes["products"].(schema.SetNestedAttribute)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected products schema", "products is not a set of nested attributes")
+		return
+	}
+
+	attributes := map[string]schema.Attribute{}
+	for name, attribute := range products.NestedObject.Attributes {
+		attributes[name] = attribute
+	}
+	attributes["id"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "NanoID of the product, either `id` or `name` has to be set",
+	}
+	attributes["name"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Exact name of the product, either `id` or `name` has to be set",
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "A single product of the seller, looked up by its id or name. " +
+			"Before this release, this data source listed all products, which is now `otc-marketplace_products`. " +
+			"See the CHANGELOG for upgrading.",
+		Attributes: attributes,
+	}
+}
+
+func (d *productDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+	}
+}
+
+func (d *productDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	clientPTR, ok := req.ProviderData.(*sellerapi.Client)
+	if !ok || clientPTR == nil {
+		resp.Diagnostics.AddError(
+			"Provider Configuration Error",
+			"The provider was not configured correctly, or the API client is missing.",
+		)
+		return
+	}
+	d.client = clientPTR
+}
+
+func (d *productDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data productDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var product *sellerapi.Product
+	if !data.Id.IsNull() {
+		product = d.productById(ctx, data.Id.ValueString(), &resp.Diagnostics)
+	} else {
+		product = d.productByName(ctx, data.Name.ValueString(), &resp.Diagnostics)
+	}
+	if product == nil {
+		return
+	}
+
+	seller, diags := newSellerValue(ctx, product.Seller)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data = productDataSourceModel{
+		ActiveRevisionId: types.StringValue(product.ActiveRevisionId),
+		CreatedAt:        types.StringValue(product.CreatedAt),
+		Eol:              types.BoolValue(product.Eol),
+		EolDate:          types.StringValue(product.EolDate),
+		Id:               types.StringValue(product.Id),
+		LicenseType:      types.StringValue(string(product.LicenseType)),
+		Name:             types.StringValue(product.Name),
+		Seller:           seller,
+		State:            types.StringValue(string(product.State)),
+		Type:             types.StringValue(string(product.Type)),
+		Weight:           types.Int64Value(product.Weight),
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *productDataSource) productById(ctx context.Context, id string, diags *diag.Diagnostics) *sellerapi.Product {
+	product, err := d.client.GetProduct(ctx, util.SanitizeString(id))
+	if util.IsNotFound(err) {
+		diags.AddAttributeError(path.Root("id"), "Product not found", fmt.Sprintf("The seller has no product with the id %q.", id))
+		return nil
+	}
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Couldn't read product %s", id),
+			fmt.Sprintf("error: %v", err),
+		)
+		return nil
+	}
+	return product
+}
+
+// productByName lists the products, as the marketplace can't look them up by name
+func (d *productDataSource) productByName(ctx context.Context, name string, diags *diag.Diagnostics) *sellerapi.Product {
+	products, err := d.client.ListProducts(ctx)
+	if err != nil {
+		diags.AddError(
+			"Couldn't list products",
+			fmt.Sprintf("error: %v", err),
+		)
+		return nil
+	}
+
+	var matching []sellerapi.Product
+	for _, product := range products {
+		if product.Name == name {
+			matching = append(matching, product)
+		}
+	}
+
+	switch len(matching) {
+	case 0:
+		diags.AddAttributeError(path.Root("name"), "Product not found", fmt.Sprintf("The seller has no product named %q.", name))
+		return nil
+	case 1:
+		return &matching[0]
+	}
+
+	ids := make([]string, 0, len(matching))
+	for _, product := range matching {
+		ids = append(ids, product.Id)
+	}
+	diags.AddAttributeError(
+		path.Root("name"),
+		"Multiple products found",
+		fmt.Sprintf("%d products are named %q (%s), look the product up by its id instead.", len(matching), name, strings.Join(ids, ", ")),
+	)
+	return nil
+}
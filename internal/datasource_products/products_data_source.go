@@ -5,39 +5,37 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"net/http"
-	"terraform-provider-otc-marketplace/internal/util"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
 )
 
-const productServicePath = "/products"
+var _ datasource.DataSource = (*productsDataSource)(nil)
 
-var _ datasource.DataSource = (*productDataSource)(nil)
-
-func NewProductDataSource() datasource.DataSource {
-	return &productDataSource{}
+func NewProductsDataSource() datasource.DataSource {
+	return &productsDataSource{}
 }
 
-type productDataSource struct {
-	client *util.MarketplaceAPIClient
+type productsDataSource struct {
+	client *sellerapi.Client
 }
 
-func (d *productDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_product"
+func (d *productsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_products"
 }
 
-func (d *productDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
-	resp.Schema = ProductsDataSourceSchema(ctx)
+func (d *productsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = productsSchema(ctx)
 }
 
-func (d *productDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+func (d *productsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
 		// to handle this gracefully. It will eventually be called with a configured provider.
 		return
 	}
 
-	clientPTR, ok := req.ProviderData.(*util.MarketplaceAPIClient)
+	clientPTR, ok := req.ProviderData.(*sellerapi.Client)
 	if !ok || clientPTR == nil {
 		resp.Diagnostics.AddError(
 			"Provider Configuration Error",
@@ -48,49 +46,39 @@ func (d *productDataSource) Configure(ctx context.Context, req datasource.Config
 	d.client = clientPTR
 }
 
-func (d *productDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data ProductsModel
+func (d *productsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data productsDataSourceModel
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	newDataNativeArrPTR, err := util.MakeMarketplaceRequest[[]util.ProductDataSourceNativeModel](ctx, http.MethodGet, productServicePath, nil, d.client)
+	filter, diags := newProductsFilter(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	products, err := d.client.ListProducts(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %v", http.MethodGet, productServicePath, nil),
+			"Couldn't list products",
 			fmt.Sprintf("error: %v", err),
 		)
 		return
 	}
 
 	var newData []attr.Value
-	for _, nativeProducts := range *newDataNativeArrPTR {
-		sellerObj, diags := NewSellerValue(SellerValue{}.AttributeTypes(ctx), map[string]attr.Value{
-			"description":   types.StringValue(nativeProducts.Seller.Description),
-			"id":            types.StringValue(nativeProducts.Seller.Id),
-			"name":          types.StringValue(nativeProducts.Seller.Name),
-			"state":         types.StringValue(nativeProducts.Seller.State),
-			"support_email": types.StringValue(nativeProducts.Seller.SupportEmail),
-			"support_url":   types.StringValue(nativeProducts.Seller.SupportUrl),
-		})
-		if diags.HasError() {
-			return
+	for _, nativeProducts := range products {
+		if !filter.matches(nativeProducts) {
+			continue
 		}
 
-		productObj, diags := NewProductsValue(ProductsValue{}.AttributeTypes(ctx), map[string]attr.Value{
-			"id":                 types.StringValue(nativeProducts.Id),
-			"name":               types.StringValue(nativeProducts.Name),
-			"created_at":         types.StringValue(nativeProducts.CreatedAt),
-			"eol":                types.BoolValue(nativeProducts.EOL),
-			"eol_date":           types.StringValue(nativeProducts.EOLDate),
-			"license_type":       types.StringValue(nativeProducts.LicenseType),
-			"seller":             sellerObj,
-			"state":              types.StringValue(nativeProducts.State),
-			"weight":             types.Int64Value(nativeProducts.Weight),
-			"type":               types.StringValue(nativeProducts.Type),
-			"active_revision_id": types.StringValue(nativeProducts.ActiveRevisionId),
-		})
-		if diags.HasError() {
+		productObj, diags := newProductsValue(ctx, nativeProducts)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 
@@ -98,16 +86,53 @@ func (d *productDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	productSet, diags := types.SetValue(ProductsValue{}.Type(ctx), newData)
-	if diags.HasError() {
-		return
-	}
-
-	data = ProductsModel{Products: productSet}
-
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	data.Products = productSet
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+func newProductsValue(ctx context.Context, product sellerapi.Product) (ProductsValue, diag.Diagnostics) {
+	sellerObj, diags := newSellerValue(ctx, product.Seller)
+	if diags.HasError() {
+		return ProductsValue{}, diags
+	}
+
+	sellerAsObj, objDiags := sellerObj.ToObjectValue(ctx)
+	diags.Append(objDiags...)
+	if diags.HasError() {
+		return ProductsValue{}, diags
+	}
+
+	productObj, productDiags := NewProductsValue(ProductsValue{}.AttributeTypes(ctx), map[string]attr.Value{
+		"id":                 types.StringValue(product.Id),
+		"name":               types.StringValue(product.Name),
+		"created_at":         types.StringValue(product.CreatedAt),
+		"eol":                types.BoolValue(product.Eol),
+		"eol_date":           types.StringValue(product.EolDate),
+		"license_type":       types.StringValue(string(product.LicenseType)),
+		"seller":             sellerAsObj,
+		"state":              types.StringValue(string(product.State)),
+		"weight":             types.Int64Value(product.Weight),
+		"type":               types.StringValue(string(product.Type)),
+		"active_revision_id": types.StringValue(product.ActiveRevisionId),
+	})
+	diags.Append(productDiags...)
+	return productObj, diags
+}
+
+func newSellerValue(ctx context.Context, seller sellerapi.Seller) (SellerValue, diag.Diagnostics) {
+	return NewSellerValue(SellerValue{}.AttributeTypes(ctx), map[string]attr.Value{
+		"description":   types.StringValue(seller.Description),
+		"id":            types.StringValue(seller.Id),
+		"name":          types.StringValue(seller.Name),
+		"state":         types.StringValue(string(seller.State)),
+		"support_email": types.StringValue(seller.SupportEmail),
+		"support_url":   types.StringValue(seller.SupportUrl),
+	})
+}
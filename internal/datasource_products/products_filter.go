@@ -0,0 +1,117 @@
+package datasource_products
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"regexp"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+)
+
+// productsDataSourceModel adds the filters to the generated model
+type productsDataSourceModel struct {
+	ProductsModel
+	Name        types.String `tfsdk:"name"`
+	NameRegex   types.String `tfsdk:"name_regex"`
+	State       types.String `tfsdk:"state"`
+	Type        types.String `tfsdk:"type"`
+	LicenseType types.String `tfsdk:"license_type"`
+	Eol         types.Bool   `tfsdk:"eol"`
+}
+
+// productsSchema adds the optional filters to the generated schema, a product has to match all of them
+func productsSchema(ctx context.Context) schema.Schema {
+	s := ProductsDataSourceSchema(ctx)
+
+	s.Attributes["name"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only return the product with exactly this name",
+	}
+	s.Attributes["name_regex"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only return products whose name matches this regular expression (RE2 syntax)",
+	}
+	s.Attributes["state"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only return products in this state, either `published` or `de-published`",
+		Validators:  []validator.String{stringvalidator.OneOf(string(sellerapi.ProductStatePublished), string(sellerapi.ProductStateDePublished))},
+	}
+	s.Attributes["type"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only return products of this deployment type, e.g. `container`",
+		Validators:  []validator.String{stringvalidator.OneOf(string(sellerapi.ProductTypeContainer))},
+	}
+	s.Attributes["license_type"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only return products with this license type",
+		Validators: []validator.String{stringvalidator.OneOf(
+			string(sellerapi.LicenseTypeOpensource),
+			string(sellerapi.LicenseTypeFree),
+			string(sellerapi.LicenseTypeTrial),
+			string(sellerapi.LicenseTypeByol),
+		)},
+	}
+	s.Attributes["eol"] = schema.BoolAttribute{
+		Optional:    true,
+		Description: "Only return products that are (`true`) or aren't (`false`) end-of-life",
+	}
+
+	return s
+}
+
+// productsFilter matches products against the filters that are set
+type productsFilter struct {
+	name        *string
+	nameRegex   *regexp.Regexp
+	state       *sellerapi.ProductState
+	productType *sellerapi.ProductType
+	licenseType *sellerapi.LicenseType
+	eol         *bool
+}
+
+func newProductsFilter(data productsDataSourceModel) (productsFilter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var filter productsFilter
+
+	if !data.Name.IsNull() {
+		filter.name = data.Name.ValueStringPointer()
+	}
+	if !data.NameRegex.IsNull() {
+		nameRegex, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", fmt.Sprintf("error: %v", err))
+		}
+		filter.nameRegex = nameRegex
+	}
+	if !data.State.IsNull() {
+		state := sellerapi.ProductState(data.State.ValueString())
+		filter.state = &state
+	}
+	if !data.Type.IsNull() {
+		productType := sellerapi.ProductType(data.Type.ValueString())
+		filter.productType = &productType
+	}
+	if !data.LicenseType.IsNull() {
+		licenseType := sellerapi.LicenseType(data.LicenseType.ValueString())
+		filter.licenseType = &licenseType
+	}
+	if !data.Eol.IsNull() {
+		filter.eol = data.Eol.ValueBoolPointer()
+	}
+
+	return filter, diags
+}
+
+func (f productsFilter) matches(product sellerapi.Product) bool {
+	return (f.name == nil || product.Name == *f.name) &&
+		(f.nameRegex == nil || f.nameRegex.MatchString(product.Name)) &&
+		(f.state == nil || product.State == *f.state) &&
+		(f.productType == nil || product.Type == *f.productType) &&
+		(f.licenseType == nil || product.LicenseType == *f.licenseType) &&
+		(f.eol == nil || product.Eol == *f.eol)
+}
@@ -5,12 +5,9 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"net/http"
-	"terraform-provider-otc-marketplace/internal/util"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
 )
 
-const profileServicePath = "/profiles/profile"
-
 var _ datasource.DataSource = (*profileDataSource)(nil)
 
 func NewProfileDataSource() datasource.DataSource {
@@ -18,24 +15,7 @@ func NewProfileDataSource() datasource.DataSource {
 }
 
 type profileDataSource struct {
-	client *util.MarketplaceAPIClient
-}
-
-type profileDataSourceNativeModel struct {
-	CustomerSupportNumber     string `json:"customer_support_number,omitempty" tfsdk:"customer_support_number"`
-	Description               string `json:"description,omitempty" tfsdk:"description"`
-	Email                     string `json:"email,omitempty" tfsdk:"email"`
-	Id                        string `json:"id,omitempty" tfsdk:"id"`
-	Name                      string `json:"name,omitempty" tfsdk:"name"`
-	Status                    string `json:"status,omitempty" tfsdk:"status"`
-	SupportEmail              string `json:"support_email,omitempty" tfsdk:"support_email"`
-	SupportUrl                string `json:"support_url,omitempty" tfsdk:"support_url"`
-	TempCustomerSupportNumber string `json:"temp_customer_support_number,omitempty" tfsdk:"temp_customer_support_number"`
-	TempDescription           string `json:"temp_description,omitempty" tfsdk:"temp_description"`
-	TempEmail                 string `json:"temp_email,omitempty" tfsdk:"temp_email"`
-	TempName                  string `json:"temp_name,omitempty" tfsdk:"temp_name"`
-	TempSupportEmail          string `json:"temp_support_email,omitempty" tfsdk:"temp_support_email"`
-	TempSupportUrl            string `json:"temp_support_url,omitempty" tfsdk:"temp_support_url"`
+	client *sellerapi.Client
 }
 
 func (d *profileDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -53,7 +33,7 @@ func (d *profileDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	clientPTR, ok := req.ProviderData.(*util.MarketplaceAPIClient)
+	clientPTR, ok := req.ProviderData.(*sellerapi.Client)
 	if !ok || clientPTR == nil {
 		resp.Diagnostics.AddError(
 			"Provider Configuration Error",
@@ -73,10 +53,10 @@ func (d *profileDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	newDataNativePTR, err := util.MakeMarketplaceRequest[profileDataSourceNativeModel](ctx, http.MethodGet, profileServicePath, nil, d.client)
+	newDataNativePTR, err := d.client.GetProfile(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %v", http.MethodGet, profileServicePath, nil),
+			"Couldn't read the seller profile",
 			fmt.Sprintf("error: %v", err),
 		)
 		return
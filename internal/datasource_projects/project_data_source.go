@@ -0,0 +1,170 @@
+package datasource_projects
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+	"terraform-provider-otc-marketplace/internal/util"
+)
+
+var _ datasource.DataSource = (*projectDataSource)(nil)
+var _ datasource.DataSourceWithConfigValidators = (*projectDataSource)(nil)
+
+// NewProjectDataSource looks up a single OTC project by its id or name
+func NewProjectDataSource() datasource.DataSource {
+	return &projectDataSource{}
+}
+
+type projectDataSource struct {
+	client *sellerapi.Client
+}
+
+type projectDataSourceModel struct {
+	Id   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func (d *projectDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+func (d *projectDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A single OTC project, looked up by its id or name. " +
+			"Before this release, this data source listed all projects, which is now `otc-marketplace_projects`. " +
+			"See the CHANGELOG for upgrading.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Unique id of the project, either `id` or `name` has to be set",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Exact name of the project, e.g. `eu-de_my_project`, either `id` or `name` has to be set",
+			},
+		},
+	}
+}
+
+func (d *projectDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+	}
+}
+
+func (d *projectDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	clientPTR, ok := req.ProviderData.(*sellerapi.Client)
+	if !ok || clientPTR == nil {
+		resp.Diagnostics.AddError(
+			"Provider Configuration Error",
+			"The provider was not configured correctly, or the API client is missing.",
+		)
+		return
+	}
+	d.client = clientPTR
+}
+
+func (d *projectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data projectDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var project *sellerapi.Project
+	if !data.Id.IsNull() {
+		project = d.projectById(ctx, data.Id.ValueString(), &resp.Diagnostics)
+	} else {
+		project = d.projectByName(ctx, data.Name.ValueString(), &resp.Diagnostics)
+	}
+	if project == nil {
+		return
+	}
+
+	data = projectDataSourceModel{
+		Id:   types.StringValue(project.Id),
+		Name: types.StringValue(project.Name),
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *projectDataSource) projectById(ctx context.Context, id string, diags *diag.Diagnostics) *sellerapi.Project {
+	project, err := d.client.GetProject(ctx, util.SanitizeString(id))
+	if util.IsNotFound(err) {
+		diags.AddAttributeError(path.Root("id"), "Project not found", fmt.Sprintf("There's no project with the id %q.", id))
+		return nil
+	}
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Couldn't read project %s", id),
+			fmt.Sprintf("error: %v", err),
+		)
+		return nil
+	}
+	return project
+}
+
+// projectByName lists the projects, as the marketplace can't look them up by name
+func (d *projectDataSource) projectByName(ctx context.Context, name string, diags *diag.Diagnostics) *sellerapi.Project {
+	projects, err := d.client.ListProjects(ctx)
+	if err != nil {
+		diags.AddError(
+			"Couldn't list projects",
+			fmt.Sprintf("error: %v", err),
+		)
+		return nil
+	}
+
+	var matching []sellerapi.Project
+	for _, project := range projects {
+		if project.Name == name {
+			matching = append(matching, project)
+		}
+	}
+
+	switch len(matching) {
+	case 0:
+		names := make([]string, 0, len(projects))
+		for _, project := range projects {
+			names = append(names, project.Name)
+		}
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Project not found",
+			fmt.Sprintf("There's no project named %q, the available projects are: %s.", name, strings.Join(names, ", ")),
+		)
+		return nil
+	case 1:
+		return &matching[0]
+	}
+
+	ids := make([]string, 0, len(matching))
+	for _, project := range matching {
+		ids = append(ids, project.Id)
+	}
+	diags.AddAttributeError(
+		path.Root("name"),
+		"Multiple projects found",
+		fmt.Sprintf("%d projects are named %q (%s), look the project up by its id instead.", len(matching), name, strings.Join(ids, ", ")),
+	)
+	return nil
+}
@@ -0,0 +1,71 @@
+package datasource_projects_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"regexp"
+	"terraform-provider-otc-marketplace/internal/acctest"
+	"terraform-provider-otc-marketplace/internal/testserver"
+	"testing"
+)
+
+func TestProjectDataSource(t *testing.T) {
+	server := acctest.NewServer(t, testserver.Options{})
+	server.Seed(testserver.Projects,
+		testserver.Object{"id": "project-a", "name": "eu-de_a"},
+		testserver.Object{"id": "project-b", "name": "eu-de_b"},
+		testserver.Object{"id": "project-c", "name": "eu-de_b"},
+	)
+
+	tests := []struct {
+		name        string
+		config      string
+		check       resource.TestCheckFunc
+		expectError *regexp.Regexp
+	}{
+		{
+			name:   "by name",
+			config: `data "otc-marketplace_project" "test" { name = "eu-de_a" }`,
+			check:  resource.TestCheckResourceAttr("data.otc-marketplace_project.test", "id", "project-a"),
+		},
+		{
+			name:   "by id",
+			config: `data "otc-marketplace_project" "test" { id = "project-b" }`,
+			check:  resource.TestCheckResourceAttr("data.otc-marketplace_project.test", "name", "eu-de_b"),
+		},
+		{
+			name:        "unknown name",
+			config:      `data "otc-marketplace_project" "test" { name = "eu-de_d" }`,
+			expectError: regexp.MustCompile(`There's no project named "eu-de_d"`),
+		},
+		{
+			name:        "unknown id",
+			config:      `data "otc-marketplace_project" "test" { id = "project-d" }`,
+			expectError: regexp.MustCompile(`There's no project with the id "project-d"`),
+		},
+		{
+			name:        "ambiguous name",
+			config:      `data "otc-marketplace_project" "test" { name = "eu-de_b" }`,
+			expectError: regexp.MustCompile(`2 projects are named "eu-de_b"`),
+		},
+		{
+			name:   "list",
+			config: `data "otc-marketplace_projects" "test" {}`,
+			check:  resource.TestCheckResourceAttr("data.otc-marketplace_projects.test", "projects.#", "3"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      acctest.ProviderConfig(server) + tt.config,
+						Check:       tt.check,
+						ExpectError: tt.expectError,
+					},
+				},
+			})
+		})
+	}
+}
@@ -4,15 +4,13 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"net/http"
-	"terraform-provider-otc-marketplace/internal/util"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-const salesHistoryServicePath = "/sales-history"
-
 var _ datasource.DataSource = (*salesHistoryDataSource)(nil)
 
 func NewSalesHistoryDataSource() datasource.DataSource {
@@ -20,18 +18,7 @@ func NewSalesHistoryDataSource() datasource.DataSource {
 }
 
 type salesHistoryDataSource struct {
-	client *util.MarketplaceAPIClient
-}
-
-type salesHistoryDataSourceNativeModel struct {
-	ProductRevisionId     string `json:"product_revision_id,omitempty"`
-	ProductId             string `json:"product_id,omitempty"`
-	ProductName           string `json:"product_name,omitempty"`
-	CustomerCompanyName   string `json:"customer_company_name,omitempty"`
-	CustomerCompanyUrl    string `json:"customer_company_url,omitempty"`
-	CustomerContactNumber string `json:"customer_contact_number,omitempty"`
-	CustomerContactEmail  string `json:"customer_contact_email,omitempty"`
-	DeployedAt            string `json:"deployed_at,omitempty"`
+	client *sellerapi.Client
 }
 
 func (d *salesHistoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -39,7 +26,7 @@ func (d *salesHistoryDataSource) Metadata(ctx context.Context, req datasource.Me
 }
 
 func (d *salesHistoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
-	resp.Schema = SalesHistoryDataSourceSchema(ctx)
+	resp.Schema = salesHistorySchema(ctx)
 }
 
 func (d *salesHistoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -49,7 +36,7 @@ func (d *salesHistoryDataSource) Configure(ctx context.Context, req datasource.C
 		return
 	}
 
-	clientPTR, ok := req.ProviderData.(*util.MarketplaceAPIClient)
+	clientPTR, ok := req.ProviderData.(*sellerapi.Client)
 	if !ok || clientPTR == nil {
 		resp.Diagnostics.AddError(
 			"Provider Configuration Error",
@@ -61,22 +48,44 @@ func (d *salesHistoryDataSource) Configure(ctx context.Context, req datasource.C
 }
 
 func (d *salesHistoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data SalesHistoryModel
+	var data salesHistoryDataSourceModel
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	newDataNativeArrPTR, err := util.MakeMarketplaceRequest[[]salesHistoryDataSourceNativeModel](ctx, http.MethodGet, salesHistoryServicePath, nil, d.client)
+	filter, diags := newSalesFilter(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sales, err := d.client.ListSalesHistory(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %v", http.MethodGet, salesHistoryServicePath, nil),
+			"Couldn't list the sales history",
 			fmt.Sprintf("error: %v", err),
 		)
 		return
 	}
 
 	var newData []attr.Value
-	for _, nativeSales := range *newDataNativeArrPTR {
+	aggregates := newSalesAggregates()
+	var unparsable []string
+	for _, nativeSales := range sales {
+		var deployedAt *time.Time
+		if parsed, err := time.Parse(time.RFC3339, nativeSales.DeployedAt); err == nil {
+			deployedAt = &parsed
+		} else {
+			unparsable = append(unparsable, nativeSales.DeployedAt)
+		}
+		// Identical sales collapse into one element of the set, so they're only counted once
+		if !filter.matches(nativeSales, deployedAt) || !aggregates.add(nativeSales, deployedAt) {
+			continue
+		}
+
 		sale, diags := NewSalesHistoryValue(SalesHistoryValue{}.AttributeTypes(ctx), map[string]attr.Value{
 			"product_revision_id":     types.StringValue(nativeSales.ProductRevisionId),
 			"product_id":              types.StringValue(nativeSales.ProductId),
@@ -87,18 +96,29 @@ func (d *salesHistoryDataSource) Read(ctx context.Context, req datasource.ReadRe
 			"customer_contact_email":  types.StringValue(nativeSales.CustomerContactEmail),
 			"deployed_at":             types.StringValue(nativeSales.DeployedAt),
 		})
-		if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 		newData = append(newData, sale)
 	}
 
+	if len(unparsable) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Sales with an invalid deployed_at",
+			fmt.Sprintf("%d sales have no RFC 3339 deployed_at, e.g. %q. They're left out of deployments_by_month, "+
+				"and of the results if deployed_after or deployed_before is set.", len(unparsable), unparsable[0]),
+		)
+	}
+
 	salesSet, diags := types.SetValue(SalesHistoryValue{}.Type(ctx), newData)
-	if diags.HasError() {
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	data = SalesHistoryModel{SalesHistory: salesSet}
+	data.SalesHistoryModel = SalesHistoryModel{SalesHistory: salesSet}
+	resp.Diagnostics.Append(aggregates.set(ctx, &data)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -0,0 +1,193 @@
+package datasource_sales_history
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+	"time"
+)
+
+// salesHistoryDataSourceModel adds the filters and aggregates to the generated model
+type salesHistoryDataSourceModel struct {
+	SalesHistoryModel
+	ProductId             types.String `tfsdk:"product_id"`
+	ProductRevisionId     types.String `tfsdk:"product_revision_id"`
+	DeployedAfter         types.String `tfsdk:"deployed_after"`
+	DeployedBefore        types.String `tfsdk:"deployed_before"`
+	TotalDeployments      types.Int64  `tfsdk:"total_deployments"`
+	DeploymentsByProduct  types.Map    `tfsdk:"deployments_by_product"`
+	DeploymentsByRevision types.Map    `tfsdk:"deployments_by_revision"`
+	DeploymentsByCustomer types.Map    `tfsdk:"deployments_by_customer"`
+	DeploymentsByMonth    types.Map    `tfsdk:"deployments_by_month"`
+}
+
+// salesHistorySchema adds the optional filters, a sale has to match all of them, and the aggregates of the matching
+// sales to the generated schema
+func salesHistorySchema(ctx context.Context) schema.Schema {
+	s := SalesHistoryDataSourceSchema(ctx)
+
+	s.Attributes["product_id"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only return sales of this product",
+	}
+	s.Attributes["product_revision_id"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only return sales of this product revision",
+	}
+	s.Attributes["deployed_after"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only return sales deployed at or after this RFC 3339 timestamp, e.g. `2025-01-01T00:00:00Z`",
+	}
+	s.Attributes["deployed_before"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only return sales deployed before this RFC 3339 timestamp",
+	}
+	s.Attributes["total_deployments"] = schema.Int64Attribute{
+		Computed:    true,
+		Description: "Number of distinct matching sales",
+	}
+	s.Attributes["deployments_by_product"] = schema.MapAttribute{
+		ElementType: types.Int64Type,
+		Computed:    true,
+		Description: "Number of distinct matching sales by product id",
+	}
+	s.Attributes["deployments_by_revision"] = schema.MapAttribute{
+		ElementType: types.Int64Type,
+		Computed:    true,
+		Description: "Number of distinct matching sales by product revision id",
+	}
+	s.Attributes["deployments_by_customer"] = schema.MapAttribute{
+		ElementType: types.Int64Type,
+		Computed:    true,
+		Description: "Number of distinct matching sales by customer company name",
+	}
+	s.Attributes["deployments_by_month"] = schema.MapAttribute{
+		ElementType: types.Int64Type,
+		Computed:    true,
+		Description: "Number of distinct matching sales by month of deployment in UTC, e.g. `2025-01`",
+	}
+
+	return s
+}
+
+// salesFilter matches sales against the filters that are set
+type salesFilter struct {
+	productId         *string
+	productRevisionId *string
+	deployedAfter     *time.Time
+	deployedBefore    *time.Time
+}
+
+func newSalesFilter(data salesHistoryDataSourceModel) (salesFilter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var filter salesFilter
+
+	if !data.ProductId.IsNull() {
+		filter.productId = data.ProductId.ValueStringPointer()
+	}
+	if !data.ProductRevisionId.IsNull() {
+		filter.productRevisionId = data.ProductRevisionId.ValueStringPointer()
+	}
+	for attribute, target := range map[string]struct {
+		value types.String
+		time  **time.Time
+	}{
+		"deployed_after":  {data.DeployedAfter, &filter.deployedAfter},
+		"deployed_before": {data.DeployedBefore, &filter.deployedBefore},
+	} {
+		if target.value.IsNull() {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, target.value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(attribute), "Invalid timestamp", fmt.Sprintf("Expected an RFC 3339 timestamp, e.g. 2025-01-01T00:00:00Z.\nerror: %v", err))
+			continue
+		}
+		*target.time = &parsed
+	}
+
+	return filter, diags
+}
+
+// hasDateRange is true if sales are filtered by when they were deployed
+func (f salesFilter) hasDateRange() bool {
+	return f.deployedAfter != nil || f.deployedBefore != nil
+}
+
+// matches reports if the sale matches the filters. deployedAt is the parsed deployed_at of the sale, or nil if it
+// couldn't be parsed, in which case the sale doesn't match a date range.
+func (f salesFilter) matches(sale sellerapi.Sale, deployedAt *time.Time) bool {
+	if (f.productId != nil && sale.ProductId != *f.productId) ||
+		(f.productRevisionId != nil && sale.ProductRevisionId != *f.productRevisionId) {
+		return false
+	}
+	if !f.hasDateRange() {
+		return true
+	}
+	return deployedAt != nil &&
+		(f.deployedAfter == nil || !deployedAt.Before(*f.deployedAfter)) &&
+		(f.deployedBefore == nil || deployedAt.Before(*f.deployedBefore))
+}
+
+// salesAggregates counts the matching sales. Identical sale records are counted once, like they're a single element
+// of the sales_history set.
+type salesAggregates struct {
+	seen       map[sellerapi.Sale]bool
+	total      int64
+	byProduct  map[string]int64
+	byRevision map[string]int64
+	byCustomer map[string]int64
+	byMonth    map[string]int64
+}
+
+func newSalesAggregates() *salesAggregates {
+	return &salesAggregates{
+		seen:       map[sellerapi.Sale]bool{},
+		byProduct:  map[string]int64{},
+		byRevision: map[string]int64{},
+		byCustomer: map[string]int64{},
+		byMonth:    map[string]int64{},
+	}
+}
+
+// add counts the sale, and returns false if an identical sale was already counted
+func (a *salesAggregates) add(sale sellerapi.Sale, deployedAt *time.Time) bool {
+	if a.seen[sale] {
+		return false
+	}
+	a.seen[sale] = true
+	a.total++
+	a.byProduct[sale.ProductId]++
+	a.byRevision[sale.ProductRevisionId]++
+	a.byCustomer[sale.CustomerCompanyName]++
+	if deployedAt != nil {
+		a.byMonth[deployedAt.UTC().Format("2006-01")]++
+	}
+	return true
+}
+
+// set stores the aggregates in the model
+func (a *salesAggregates) set(ctx context.Context, data *salesHistoryDataSourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.TotalDeployments = types.Int64Value(a.total)
+	for _, aggregate := range []struct {
+		counts map[string]int64
+		target *types.Map
+	}{
+		{a.byProduct, &data.DeploymentsByProduct},
+		{a.byRevision, &data.DeploymentsByRevision},
+		{a.byCustomer, &data.DeploymentsByCustomer},
+		{a.byMonth, &data.DeploymentsByMonth},
+	} {
+		value, mapDiags := types.MapValueFrom(ctx, types.Int64Type, aggregate.counts)
+		diags.Append(mapDiags...)
+		*aggregate.target = value
+	}
+
+	return diags
+}
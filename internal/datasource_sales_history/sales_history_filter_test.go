@@ -0,0 +1,159 @@
+package datasource_sales_history
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"maps"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+	"terraform-provider-otc-marketplace/internal/util"
+	"testing"
+	"time"
+)
+
+// parseDeployedAt parses deployed_at like Read does, nil if it isn't RFC 3339
+func parseDeployedAt(sale sellerapi.Sale) *time.Time {
+	parsed, err := time.Parse(time.RFC3339, sale.DeployedAt)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
+
+func TestSalesFilterMatches(t *testing.T) {
+	sale := func(deployedAt string) sellerapi.Sale {
+		return sellerapi.Sale{ProductId: "product", ProductRevisionId: "revision", DeployedAt: deployedAt}
+	}
+
+	tests := []struct {
+		name              string
+		productId         string
+		productRevisionId string
+		deployedAfter     string
+		deployedBefore    string
+		sale              sellerapi.Sale
+		want              bool
+	}{
+		{name: "no filters", sale: sale("2025-01-15T10:00:00Z"), want: true},
+		{name: "product", productId: "product", sale: sale("2025-01-15T10:00:00Z"), want: true},
+		{name: "other product", productId: "other", sale: sale("2025-01-15T10:00:00Z"), want: false},
+		{name: "revision", productRevisionId: "revision", sale: sale("2025-01-15T10:00:00Z"), want: true},
+		{name: "other revision", productRevisionId: "other", sale: sale("2025-01-15T10:00:00Z"), want: false},
+		{name: "deployed_after is inclusive", deployedAfter: "2025-01-15T10:00:00Z", sale: sale("2025-01-15T10:00:00Z"), want: true},
+		{name: "before deployed_after", deployedAfter: "2025-01-15T10:00:00Z", sale: sale("2025-01-15T09:59:59Z"), want: false},
+		{name: "deployed_before is exclusive", deployedBefore: "2025-01-15T10:00:00Z", sale: sale("2025-01-15T10:00:00Z"), want: false},
+		{name: "before deployed_before", deployedBefore: "2025-01-15T10:00:00Z", sale: sale("2025-01-15T09:59:59Z"), want: true},
+		{name: "offsets are compared as instants", deployedAfter: "2025-01-15T10:00:00Z", sale: sale("2025-01-15T11:00:00+01:00"), want: true},
+		{
+			name:          "within range",
+			deployedAfter: "2025-01-01T00:00:00Z", deployedBefore: "2025-02-01T00:00:00Z",
+			sale: sale("2025-01-31T23:59:59Z"), want: true,
+		},
+		{name: "unparsable deployed_at without range", sale: sale("yesterday"), want: true},
+		{name: "empty deployed_at without range", productId: "product", sale: sale(""), want: true},
+		{name: "unparsable deployed_at with deployed_after", deployedAfter: "2025-01-01T00:00:00Z", sale: sale("yesterday"), want: false},
+		{name: "unparsable deployed_at with deployed_before", deployedBefore: "2030-01-01T00:00:00Z", sale: sale("yesterday"), want: false},
+		{
+			name:      "all filters",
+			productId: "product", productRevisionId: "revision",
+			deployedAfter: "2025-01-01T00:00:00Z", deployedBefore: "2025-02-01T00:00:00Z",
+			sale: sale("2025-01-15T10:00:00Z"), want: true,
+		},
+		{
+			name:      "all filters but the revision",
+			productId: "product", productRevisionId: "other",
+			deployedAfter: "2025-01-01T00:00:00Z", deployedBefore: "2025-02-01T00:00:00Z",
+			sale: sale("2025-01-15T10:00:00Z"), want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, diags := newSalesFilter(salesHistoryDataSourceModel{
+				ProductId:         util.StringSetOrNull(tt.productId),
+				ProductRevisionId: util.StringSetOrNull(tt.productRevisionId),
+				DeployedAfter:     util.StringSetOrNull(tt.deployedAfter),
+				DeployedBefore:    util.StringSetOrNull(tt.deployedBefore),
+			})
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if got := filter.matches(tt.sale, parseDeployedAt(tt.sale)); got != tt.want {
+				t.Errorf("expected %t, got %t", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewSalesFilterInvalidTimestamp(t *testing.T) {
+	_, diags := newSalesFilter(salesHistoryDataSourceModel{
+		DeployedAfter:  types.StringValue("2025-01-01"),
+		DeployedBefore: types.StringValue("2025-02-01T00:00:00Z"),
+	})
+	if diags.ErrorsCount() != 1 {
+		t.Errorf("expected an error for deployed_after, got %v", diags)
+	}
+}
+
+func TestSalesAggregates(t *testing.T) {
+	sales := []sellerapi.Sale{
+		{ProductId: "a", ProductRevisionId: "a1", CustomerCompanyName: "ACME", DeployedAt: "2025-01-31T23:30:00Z"},
+		// February in UTC, January in the offset
+		{ProductId: "a", ProductRevisionId: "a2", CustomerCompanyName: "ACME", DeployedAt: "2025-01-31T23:30:00-02:00"},
+		// January in UTC, February in the offset
+		{ProductId: "b", ProductRevisionId: "b1", CustomerCompanyName: "Initech", DeployedAt: "2025-02-01T00:30:00+01:00"},
+		// Counted everywhere but by month
+		{ProductId: "b", ProductRevisionId: "b1", CustomerCompanyName: "Initech", DeployedAt: "not a date"},
+		// Identical to the first sale, so it's a single element of the set and counted once
+		{ProductId: "a", ProductRevisionId: "a1", CustomerCompanyName: "ACME", DeployedAt: "2025-01-31T23:30:00Z"},
+	}
+
+	aggregates := newSalesAggregates()
+	var added int
+	for _, sale := range sales {
+		if aggregates.add(sale, parseDeployedAt(sale)) {
+			added++
+		}
+	}
+
+	if added != 4 {
+		t.Errorf("expected the duplicate sale to be skipped, added %d sales", added)
+	}
+
+	if aggregates.total != 4 {
+		t.Errorf("total: expected 4, got %d", aggregates.total)
+	}
+	for name, tt := range map[string]struct {
+		got  map[string]int64
+		want map[string]int64
+	}{
+		"by product":  {aggregates.byProduct, map[string]int64{"a": 2, "b": 2}},
+		"by revision": {aggregates.byRevision, map[string]int64{"a1": 1, "a2": 1, "b1": 2}},
+		"by customer": {aggregates.byCustomer, map[string]int64{"ACME": 2, "Initech": 2}},
+		"by month":    {aggregates.byMonth, map[string]int64{"2025-01": 2, "2025-02": 1}},
+	} {
+		if !maps.Equal(tt.got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", name, tt.want, tt.got)
+		}
+	}
+
+	var data salesHistoryDataSourceModel
+	if diags := aggregates.set(context.Background(), &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if data.TotalDeployments.ValueInt64() != 4 || len(data.DeploymentsByMonth.Elements()) != 2 {
+		t.Errorf("expected the aggregates in the model, got %d sales in %d months",
+			data.TotalDeployments.ValueInt64(), len(data.DeploymentsByMonth.Elements()))
+	}
+}
+
+func TestSalesAggregatesEmpty(t *testing.T) {
+	var data salesHistoryDataSourceModel
+	if diags := newSalesAggregates().set(context.Background(), &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	// Empty maps rather than null, so lookups in the configuration don't fail
+	if data.TotalDeployments.ValueInt64() != 0 || data.DeploymentsByMonth.IsNull() {
+		t.Errorf("expected zero sales and empty maps, got %d sales and %v", data.TotalDeployments.ValueInt64(), data.DeploymentsByMonth)
+	}
+}
@@ -5,12 +5,9 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"net/http"
-	"terraform-provider-otc-marketplace/internal/util"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
 )
 
-const whoamiServicePath = "/whoami"
-
 var _ datasource.DataSource = (*whoamiDataSource)(nil)
 
 func NewWhoamiDataSource() datasource.DataSource {
@@ -18,15 +15,7 @@ func NewWhoamiDataSource() datasource.DataSource {
 }
 
 type whoamiDataSource struct {
-	client *util.MarketplaceAPIClient
-}
-
-// Needed for JSON decoding. Reflection is too expensive and complicated as it will need to go both ways.
-type whoamiDataSourceNativeModel struct {
-	DomainName    string `json:"domain_name,omitempty" tfsdk:"domain_name"`
-	LastProjectId string `json:"last_project_id,omitempty" tfsdk:"last_project_id"`
-	Username      string `json:"username,omitempty" tfsdk:"username"`
-	LLMHub        bool   `json:"llm_hub,omitempty" tfsdk:"llm_hub"`
+	client *sellerapi.Client
 }
 
 func (d *whoamiDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -44,7 +33,7 @@ func (d *whoamiDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	clientPTR, ok := req.ProviderData.(*util.MarketplaceAPIClient)
+	clientPTR, ok := req.ProviderData.(*sellerapi.Client)
 	if !ok || clientPTR == nil {
 		resp.Diagnostics.AddError(
 			"Provider Configuration Error",
@@ -64,10 +53,10 @@ func (d *whoamiDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	newDataNativePTR, err := util.MakeMarketplaceRequest[whoamiDataSourceNativeModel](ctx, http.MethodGet, whoamiServicePath, nil, d.client)
+	newDataNativePTR, err := d.client.WhoAmI(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %v", http.MethodGet, whoamiServicePath, nil),
+			"Couldn't read the logged in user",
 			fmt.Sprintf("error: %v", err),
 		)
 		return
@@ -75,7 +64,7 @@ func (d *whoamiDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	data.DomainName = types.StringValue(newDataNativePTR.DomainName)
 	data.Username = types.StringValue(newDataNativePTR.Username)
-	data.LlmHub = types.BoolValue(newDataNativePTR.LLMHub)
+	data.LlmHub = types.BoolValue(newDataNativePTR.LlmHub)
 	data.LastProjectId = types.StringValue(newDataNativePTR.LastProjectId)
 
 	// Save data into Terraform state
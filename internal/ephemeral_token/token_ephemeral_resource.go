@@ -0,0 +1,173 @@
+package ephemeral_token
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+	"terraform-provider-otc-marketplace/internal/util"
+	"time"
+)
+
+var _ ephemeral.EphemeralResource = (*tokenEphemeralResource)(nil)
+var _ ephemeral.EphemeralResourceWithConfigure = (*tokenEphemeralResource)(nil)
+var _ ephemeral.EphemeralResourceWithClose = (*tokenEphemeralResource)(nil)
+
+// privateTokenKey stores the token between Open and Close, private data isn't written to the plan or state
+const privateTokenKey = "token"
+
+// TokenSource logs in separately from the provider, so logging the token out doesn't revoke the token the provider
+// uses for its own requests
+type TokenSource struct {
+	Login   func(ctx context.Context) (*util.MarketplaceAPIClient, error)
+	Options util.ClientOptions
+}
+
+// NewTokenEphemeralResource exposes a Seller API token for calls the provider doesn't cover
+func NewTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &tokenEphemeralResource{}
+}
+
+type tokenEphemeralResource struct {
+	source *TokenSource
+}
+
+type tokenEphemeralResourceModel struct {
+	Endpoint  types.String `tfsdk:"endpoint"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+	Token     types.String `tfsdk:"token"`
+}
+
+// privateToken is what Close needs to log the token out
+type privateToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (e *tokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_token"
+}
+
+func (e *tokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A short-lived Seller API token, logged in separately from the provider and logged out once " +
+			"Terraform is done with it. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				Computed:    true,
+				Description: "Base URL of the Seller API the token is valid for",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the token expires, as an RFC 3339 timestamp",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "JWT to send as `Authorization: Bearer <token>`",
+			},
+		},
+	}
+}
+
+func (e *tokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	source, ok := req.ProviderData.(*TokenSource)
+	if !ok || source == nil {
+		resp.Diagnostics.AddError(
+			"Provider Configuration Error",
+			"The provider was not configured correctly, or the API client is missing.",
+		)
+		return
+	}
+	e.source = source
+}
+
+func (e *tokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	client, err := e.source.Login(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Couldn't log in",
+			fmt.Sprintf("error: %v", err),
+		)
+		return
+	}
+	token, err := client.Token(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Couldn't log in",
+			fmt.Sprintf("error: %v", err),
+		)
+		return
+	}
+	expiresAt := client.TokenExpiry()
+
+	private, err := json.Marshal(privateToken{Token: token, ExpiresAt: expiresAt})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Couldn't store the token for logging out",
+			fmt.Sprintf("error: %v", err),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateTokenKey, private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := tokenEphemeralResourceModel{
+		Endpoint:  types.StringValue(client.BaseURL),
+		ExpiresAt: types.StringValue(expiresAt.UTC().Format(time.RFC3339)),
+		Token:     types.StringValue(token),
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// Close logs the token out, so it can't be used once Terraform is done with it
+func (e *tokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	private, diags := req.Private.GetKey(ctx, privateTokenKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || private == nil {
+		return
+	}
+
+	var token privateToken
+	if err := json.Unmarshal(private, &token); err != nil {
+		resp.Diagnostics.AddError(
+			"Couldn't read the token to log it out",
+			fmt.Sprintf("error: %v", err),
+		)
+		return
+	}
+	if time.Now().After(token.ExpiresAt) {
+		tflog.Debug(ctx, "marketplace token already expired, not logging it out")
+		return
+	}
+
+	// Without login credentials, the client can only use the token it's given
+	api, err := util.NewMarketplaceAPIClient(nil, e.source.Options)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Couldn't create the API client",
+			fmt.Sprintf("error: %v", err),
+		)
+		return
+	}
+	api.UseToken(token.Token, token.ExpiresAt)
+
+	if err := sellerapi.NewClient(api).Logout(ctx); err != nil {
+		resp.Diagnostics.AddWarning(
+			"Couldn't log out the marketplace token",
+			fmt.Sprintf("The token stays valid until %s. error: %v", token.ExpiresAt.UTC().Format(time.RFC3339), err),
+		)
+	}
+}
@@ -0,0 +1,330 @@
+package functions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+)
+
+var _ function.Function = (*configTemplateFunction)(nil)
+
+// NewConfigTemplateFunction builds an entry of `product_revision_application_configuration`
+func NewConfigTemplateFunction() function.Function {
+	return &configTemplateFunction{}
+}
+
+type configTemplateFunction struct{}
+
+// configTemplateAttributeTypes are the attributes of a `product_revision_application_configuration` entry
+var configTemplateAttributeTypes = map[string]attr.Type{
+	"confidential":  types.BoolType,
+	"default_value": types.StringType,
+	"hidden":        types.BoolType,
+	"hint":          types.StringType,
+	"input_type":    types.StringType,
+	"key":           types.StringType,
+	"label":         types.StringType,
+	"multiple":      types.BoolType,
+	"required":      types.BoolType,
+	"tooltip":       types.StringType,
+	"validation": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+		"message": types.StringType,
+		"pattern": types.StringType,
+	}}},
+	"values": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+		"label": types.StringType,
+		"value": types.StringType,
+	}}},
+}
+
+// configTemplateSettings are the attributes that can be passed as settings, key and input_type are arguments
+var configTemplateSettings = []string{
+	"confidential", "default_value", "hidden", "hint", "label", "multiple", "required", "tooltip", "validation", "values",
+}
+
+func (f *configTemplateFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "config_template"
+}
+
+func (f *configTemplateFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds an entry of a product revision's configuration template",
+		Description: "Returns an object for `product_revision_application_configuration`. The settings are checked " +
+			"against the input type: a `switch` defaults to `true` or `false`, a `selection` needs `values` and its " +
+			"`default_value` has to be one of them, and only a selection can allow `multiple` values.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "key",
+				Description: "Key of the value in the Helm chart, e.g. `replicaCount`",
+			},
+			function.StringParameter{
+				Name:        "input_type",
+				Description: "One of `text`, `switch` or `selection`",
+			},
+			function.DynamicParameter{
+				Name: "settings",
+				Description: "Object with the optional settings: `label`, `hint`, `tooltip`, `default_value`, " +
+					"`required`, `confidential`, `hidden`, `multiple`, `values` (strings or objects with `label` and " +
+					"`value`) and `validation` (an object or list of objects with `pattern` and `message`)",
+				AllowNullValue: true,
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: configTemplateAttributeTypes,
+		},
+	}
+}
+
+func (f *configTemplateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var key, inputType string
+	var settings types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &key, &inputType, &settings))
+	if resp.Error != nil {
+		return
+	}
+
+	if strings.TrimSpace(key) == "" {
+		resp.Error = function.NewArgumentFuncError(0, "The key can't be empty.")
+		return
+	}
+	template := sellerapi.ConfigurationTemplate{Key: key, InputType: sellerapi.InputType(inputType)}
+	switch template.InputType {
+	case sellerapi.InputTypeText, sellerapi.InputTypeSwitch, sellerapi.InputTypeSelection:
+	default:
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("The input type has to be one of text, switch or selection, got %q.", inputType))
+		return
+	}
+
+	if err := applySettings(ctx, &template, settings); err != nil {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("Invalid settings for %s: %v", key, err))
+		return
+	}
+	if err := checkConfigTemplate(template); err != nil {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("Invalid settings for %s: %v", key, err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, template))
+}
+
+// applySettings copies the settings into the template, checking the type of each of them
+func applySettings(ctx context.Context, template *sellerapi.ConfigurationTemplate, settings types.Dynamic) error {
+	if settings.IsNull() || settings.IsUnderlyingValueNull() {
+		return nil
+	}
+	attributes, ok := objectAttributes(settings.UnderlyingValue())
+	if !ok {
+		return fmt.Errorf("expected an object, got %s", settings.UnderlyingValue().Type(ctx))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		value := attributes[name]
+		if value.IsNull() {
+			continue
+		}
+
+		var err error
+		switch name {
+		case "label":
+			template.Label, err = stringSetting(ctx, value)
+		case "hint":
+			template.Hint, err = stringSetting(ctx, value)
+		case "tooltip":
+			template.Tooltip, err = stringSetting(ctx, value)
+		case "default_value":
+			var defaultValue string
+			defaultValue, err = defaultValueSetting(ctx, value)
+			template.DefaultValue = sellerapi.DefaultValue(defaultValue)
+		case "required":
+			template.Required, err = boolSetting(ctx, value)
+		case "confidential":
+			template.Confidential, err = boolSetting(ctx, value)
+		case "hidden":
+			template.Hidden, err = boolSetting(ctx, value)
+		case "multiple":
+			template.Multiple, err = boolSetting(ctx, value)
+		case "values":
+			template.Values, err = valuesSetting(ctx, value)
+		case "validation":
+			template.Validation, err = validationSetting(ctx, value)
+		default:
+			err = fmt.Errorf("unknown setting, the supported settings are: %s", strings.Join(configTemplateSettings, ", "))
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// checkConfigTemplate checks the settings fit the input type, and that the default value is allowed by the template
+func checkConfigTemplate(template sellerapi.ConfigurationTemplate) error {
+	switch template.InputType {
+	case sellerapi.InputTypeSelection:
+		if len(template.Values) == 0 {
+			return errors.New("a selection needs values to choose from")
+		}
+	default:
+		if len(template.Values) > 0 {
+			return fmt.Errorf("only a selection has values, not a %s", template.InputType)
+		}
+		if template.Multiple {
+			return fmt.Errorf("only a selection can allow multiple values, not a %s", template.InputType)
+		}
+	}
+
+	if template.DefaultValue == "" {
+		return nil
+	}
+	if problems := template.ValidateValue(string(template.DefaultValue)); len(problems) > 0 {
+		return fmt.Errorf("default_value: %s", strings.Join(problems, " "))
+	}
+	return nil
+}
+
+func stringSetting(ctx context.Context, value attr.Value) (string, error) {
+	s, ok := value.(basetypes.StringValue)
+	if !ok {
+		return "", fmt.Errorf("expected a string, got %s", value.Type(ctx))
+	}
+	return s.ValueString(), nil
+}
+
+// boolSetting also accepts "true" and "false", as all values of a map have the same type
+func boolSetting(ctx context.Context, value attr.Value) (bool, error) {
+	switch value := value.(type) {
+	case basetypes.BoolValue:
+		return value.ValueBool(), nil
+	case basetypes.StringValue:
+		if b, err := strconv.ParseBool(value.ValueString()); err == nil {
+			return b, nil
+		}
+	}
+	return false, fmt.Errorf("expected a bool, got %s", value.Type(ctx))
+}
+
+// defaultValueSetting accepts a bool for a switch and a number for a text, the marketplace stores all of them as strings
+func defaultValueSetting(ctx context.Context, value attr.Value) (string, error) {
+	switch value := value.(type) {
+	case basetypes.StringValue:
+		return value.ValueString(), nil
+	case basetypes.BoolValue:
+		return strconv.FormatBool(value.ValueBool()), nil
+	case basetypes.NumberValue:
+		return value.ValueBigFloat().Text('f', -1), nil
+	}
+	return "", fmt.Errorf("expected a string, bool or number, got %s", value.Type(ctx))
+}
+
+// valuesSetting accepts a list of strings, used as both label and value, or of objects with a label and value
+func valuesSetting(ctx context.Context, value attr.Value) ([]sellerapi.ConfigurationOption, error) {
+	elements, ok := listElements(value)
+	if !ok {
+		return nil, fmt.Errorf("expected a list, got %s", value.Type(ctx))
+	}
+	// An empty list is stored as null, like the marketplace's response
+	if len(elements) == 0 {
+		return nil, nil
+	}
+
+	options := make([]sellerapi.ConfigurationOption, 0, len(elements))
+	for i, element := range elements {
+		if s, ok := element.(basetypes.StringValue); ok {
+			options = append(options, sellerapi.ConfigurationOption{Label: s.ValueString(), Value: s.ValueString()})
+			continue
+		}
+
+		settings, err := objectSettings(ctx, element, "value", "label")
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+		option := sellerapi.ConfigurationOption{Label: settings["label"], Value: settings["value"]}
+		if option.Label == "" {
+			option.Label = option.Value
+		}
+		options = append(options, option)
+	}
+	return options, nil
+}
+
+// validationSetting accepts a single object with a pattern and message or a list of them
+func validationSetting(ctx context.Context, value attr.Value) ([]sellerapi.ConfigurationValidation, error) {
+	elements, ok := listElements(value)
+	if !ok {
+		elements = []attr.Value{value}
+	}
+	if len(elements) == 0 {
+		return nil, nil
+	}
+
+	validations := make([]sellerapi.ConfigurationValidation, 0, len(elements))
+	for i, element := range elements {
+		settings, err := objectSettings(ctx, element, "pattern", "message")
+		if err != nil {
+			return nil, fmt.Errorf("validation %d: %w", i, err)
+		}
+		validations = append(validations, sellerapi.ConfigurationValidation{Pattern: settings["pattern"], Message: settings["message"]})
+	}
+	return validations, nil
+}
+
+// objectSettings returns the string attributes of an object, the first name is required
+func objectSettings(ctx context.Context, value attr.Value, names ...string) (map[string]string, error) {
+	attributes, ok := objectAttributes(value)
+	if !ok {
+		return nil, fmt.Errorf("expected an object with %s, got %s", strings.Join(names, " and "), value.Type(ctx))
+	}
+
+	settings := map[string]string{}
+	for name, attribute := range attributes {
+		if !slices.Contains(names, name) {
+			return nil, fmt.Errorf("unknown attribute %s, expected %s", name, strings.Join(names, " and "))
+		}
+		if attribute.IsNull() {
+			continue
+		}
+		s, err := stringSetting(ctx, attribute)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		settings[name] = s
+	}
+	if settings[names[0]] == "" {
+		return nil, fmt.Errorf("%s is required", names[0])
+	}
+	return settings, nil
+}
+
+// objectAttributes returns the attributes of an object, or the elements of a map
+func objectAttributes(value attr.Value) (map[string]attr.Value, bool) {
+	switch value := value.(type) {
+	case basetypes.ObjectValue:
+		return value.Attributes(), true
+	case basetypes.MapValue:
+		return value.Elements(), true
+	}
+	return nil, false
+}
+
+// listElements returns the elements of a list, tuple or set, HCL creates a tuple for `[...]`
+func listElements(value attr.Value) ([]attr.Value, bool) {
+	switch value := value.(type) {
+	case basetypes.ListValue:
+		return value.Elements(), true
+	case basetypes.TupleValue:
+		return value.Elements(), true
+	case basetypes.SetValue:
+		return value.Elements(), true
+	}
+	return nil, false
+}
@@ -0,0 +1,63 @@
+package functions
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"os"
+	"terraform-provider-otc-marketplace/internal/util"
+)
+
+var _ function.Function = (*iconDataUriFunction)(nil)
+
+// NewIconDataUriFunction turns a local icon or its base64 content into the data URI the marketplace expects
+func NewIconDataUriFunction() function.Function {
+	return &iconDataUriFunction{}
+}
+
+type iconDataUriFunction struct{}
+
+func (f *iconDataUriFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "icon_data_uri"
+}
+
+func (f *iconDataUriFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns a product revision icon as a data URI",
+		Description: "Reads a PNG, JPEG or GIF icon from a path, or decodes it from base64 content e.g. returned by " +
+			"`filebase64()`, checks it's in 16:9 format, and returns it as a data URI.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "path_or_bytes",
+				Description: "Path of the icon or its base64 encoded content",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *iconDataUriFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var pathOrBytes string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &pathOrBytes))
+	if resp.Error != nil {
+		return
+	}
+
+	var icon *util.File
+	var err error
+	if _, statErr := os.Stat(pathOrBytes); statErr == nil {
+		icon, err = util.LoadIcon(pathOrBytes)
+	} else if content, decodeErr := base64.StdEncoding.DecodeString(pathOrBytes); decodeErr == nil {
+		icon, err = util.NewIcon("", content)
+	} else {
+		err = fmt.Errorf("neither a readable file nor base64 content: %w", statErr)
+	}
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid icon: %v", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, icon.DataURI()))
+}
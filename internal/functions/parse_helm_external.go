@@ -0,0 +1,106 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"net/url"
+	"strings"
+)
+
+var _ function.Function = (*parseHelmExternalFunction)(nil)
+
+// NewParseHelmExternalFunction splits the `helm_external` URL of a product revision into its parts
+func NewParseHelmExternalFunction() function.Function {
+	return &parseHelmExternalFunction{}
+}
+
+type parseHelmExternalFunction struct{}
+
+type helmExternal struct {
+	Registry   string `tfsdk:"registry"`
+	Repository string `tfsdk:"repository"`
+	Chart      string `tfsdk:"chart"`
+	Version    string `tfsdk:"version"`
+	ChartUrl   string `tfsdk:"chart_url"`
+}
+
+func (f *parseHelmExternalFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_helm_external"
+}
+
+func (f *parseHelmExternalFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Splits a Helm chart URL into its parts",
+		Description: "Parses an `oci://` chart URL as used for `helm_external`, e.g. " +
+			"`oci://registry-1.docker.io/bitnamicharts/wordpress:1.2.1`, into the registry, repository, chart and " +
+			"version after the `:`. `chart_url` is the URL without the version.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "url",
+				Description: "The Helm chart URL",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"registry":   types.StringType,
+				"repository": types.StringType,
+				"chart":      types.StringType,
+				"version":    types.StringType,
+				"chart_url":  types.StringType,
+			},
+		},
+	}
+}
+
+func (f *parseHelmExternalFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rawUrl string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &rawUrl))
+	if resp.Error != nil {
+		return
+	}
+
+	chart, err := parseHelmExternal(rawUrl)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid Helm chart URL %q: %v", rawUrl, err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, chart))
+}
+
+func parseHelmExternal(rawUrl string) (*helmExternal, error) {
+	parsed, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+	// The marketplace only deploys charts from OCI registries
+	if parsed.Scheme != "oci" {
+		return nil, fmt.Errorf("the URL has to start with oci://")
+	}
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("the URL has no registry")
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	name, version, _ := strings.Cut(segments[len(segments)-1], ":")
+	if name == "" {
+		return nil, fmt.Errorf("the URL has no chart name")
+	}
+	repository := strings.Join(segments[:len(segments)-1], "/")
+	chartUrl := "oci://" + parsed.Host + "/" + name
+	if repository != "" {
+		chartUrl = "oci://" + parsed.Host + "/" + repository + "/" + name
+	}
+
+	return &helmExternal{
+		Registry:   parsed.Host,
+		Repository: repository,
+		Chart:      name,
+		Version:    version,
+		ChartUrl:   chartUrl,
+	}, nil
+}
@@ -0,0 +1,46 @@
+package functions
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"terraform-provider-otc-marketplace/internal/util"
+)
+
+var _ function.Function = (*validateNanoidFunction)(nil)
+
+// NewValidateNanoidFunction checks ids before they're passed to the marketplace
+func NewValidateNanoidFunction() function.Function {
+	return &validateNanoidFunction{}
+}
+
+type validateNanoidFunction struct{}
+
+func (f *validateNanoidFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_nanoid"
+}
+
+func (f *validateNanoidFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks whether a string is a marketplace id",
+		Description: "Returns `true` if the id is a NanoID as used for all marketplace objects, i.e. it matches " +
+			"`^[a-zA-Z0-9_-]{21,}$`. Meant for the `validation` blocks of module variables.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The id to check",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *validateNanoidFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, util.IsNanoID(id)))
+}
@@ -0,0 +1,190 @@
+package provider_marketplace
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+const (
+	envDomainName            = "OTC_MARKETPLACE_DOMAIN_NAME"
+	envUsername              = "OTC_MARKETPLACE_USERNAME"
+	envPassword              = "OTC_MARKETPLACE_PASSWORD"
+	envUserId                = "OTC_MARKETPLACE_USER_ID"
+	envPasscode              = "OTC_MARKETPLACE_PASSCODE"
+	envTotpSecret            = "OTC_MARKETPLACE_TOTP_SECRET"
+	envSharedCredentialsFile = "OTC_MARKETPLACE_SHARED_CREDENTIALS_FILE"
+	envProfile               = "OTC_MARKETPLACE_PROFILE"
+	envEndpoint              = "OTC_MARKETPLACE_ENDPOINT"
+	envCAFile                = "OTC_MARKETPLACE_CA_FILE"
+	envInsecure              = "OTC_MARKETPLACE_INSECURE"
+	envProxyURL              = "OTC_MARKETPLACE_PROXY_URL"
+	envTimeout               = "OTC_MARKETPLACE_TIMEOUT"
+	envMaxRetries            = "OTC_MARKETPLACE_MAX_RETRIES"
+)
+
+// cloudsFile is the subset of an OpenStack clouds.yaml that's needed to log in to the marketplace
+type cloudsFile struct {
+	Clouds map[string]struct {
+		Auth cloudAuth `yaml:"auth"`
+	} `yaml:"clouds"`
+}
+
+type cloudAuth struct {
+	DomainName string `yaml:"domain_name"`
+	// OTC clouds.yaml files usually only set the domain of the user
+	UserDomainName string `yaml:"user_domain_name"`
+	Username       string `yaml:"username"`
+	Password       string `yaml:"password"`
+	UserId         string `yaml:"user_id"`
+	Passcode       string `yaml:"passcode"`
+	TotpSecret     string `yaml:"totp_secret"`
+}
+
+// defaultCredentialsFiles are searched in order if a profile is set but no file is, like the OpenStack tools do
+func defaultCredentialsFiles() []string {
+	files := []string{"clouds.yaml"}
+	if home, err := os.UserHomeDir(); err == nil {
+		files = append(files, filepath.Join(home, ".config", "openstack", "clouds.yaml"))
+	}
+	return append(files, "/etc/openstack/clouds.yaml")
+}
+
+// stringOrEnv returns v if it's set in the provider block, otherwise the value of the environment variable
+func stringOrEnv(v types.String, env string) types.String {
+	if isSet(v) {
+		return v
+	}
+	if value, ok := os.LookupEnv(env); ok && value != "" {
+		return types.StringValue(value)
+	}
+	return v
+}
+
+// boolOrEnv returns v if it's set in the provider block, otherwise the value of the environment variable
+func boolOrEnv(v types.Bool, env string) (types.Bool, error) {
+	if !v.IsNull() && !v.IsUnknown() {
+		return v, nil
+	}
+	value, ok := os.LookupEnv(env)
+	if !ok || value == "" {
+		return v, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return v, fmt.Errorf("%s must be a boolean, got %q", env, value)
+	}
+	return types.BoolValue(parsed), nil
+}
+
+// int64OrEnv returns v if it's set in the provider block, otherwise the value of the environment variable
+func int64OrEnv(v types.Int64, env string) (types.Int64, error) {
+	if !v.IsNull() && !v.IsUnknown() {
+		return v, nil
+	}
+	value, ok := os.LookupEnv(env)
+	if !ok || value == "" {
+		return v, nil
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return v, fmt.Errorf("%s must be an integer, got %q", env, value)
+	}
+	return types.Int64Value(parsed), nil
+}
+
+// stringOrProfile returns v if it's already set, otherwise the value from the profile
+func stringOrProfile(v types.String, value string) types.String {
+	if isSet(v) || value == "" {
+		return v
+	}
+	return types.StringValue(value)
+}
+
+// resolveCredentials fills in the login attributes that are missing from the provider block. Each attribute is taken
+// from the first source that sets it:
+//  1. the provider block
+//  2. the OTC_MARKETPLACE_* environment variables
+//  3. the profile in the shared credentials file
+func resolveCredentials(config marketplaceProvider) (marketplaceProvider, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	config.DomainName = stringOrEnv(config.DomainName, envDomainName)
+	config.Username = stringOrEnv(config.Username, envUsername)
+	config.Password = stringOrEnv(config.Password, envPassword)
+	config.UserId = stringOrEnv(config.UserId, envUserId)
+	config.Passcode = stringOrEnv(config.Passcode, envPasscode)
+	config.TotpSecret = stringOrEnv(config.TotpSecret, envTotpSecret)
+	config.SharedCredentialsFile = stringOrEnv(config.SharedCredentialsFile, envSharedCredentialsFile)
+	config.Profile = stringOrEnv(config.Profile, envProfile)
+
+	if !isSet(config.Profile) {
+		if isSet(config.SharedCredentialsFile) {
+			diags.AddAttributeWarning(path.Root("shared_credentials_file"), "Ignored Configuration",
+				"'shared_credentials_file' is only read if 'profile' is set.")
+		}
+		return config, diags
+	}
+
+	auth, err := readProfile(config.SharedCredentialsFile.ValueString(), config.Profile.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("profile"), "Invalid Credentials File", err.Error())
+		return config, diags
+	}
+
+	domainName := auth.DomainName
+	if domainName == "" {
+		domainName = auth.UserDomainName
+	}
+	config.DomainName = stringOrProfile(config.DomainName, domainName)
+	config.Username = stringOrProfile(config.Username, auth.Username)
+	config.Password = stringOrProfile(config.Password, auth.Password)
+	config.UserId = stringOrProfile(config.UserId, auth.UserId)
+	config.Passcode = stringOrProfile(config.Passcode, auth.Passcode)
+	config.TotpSecret = stringOrProfile(config.TotpSecret, auth.TotpSecret)
+
+	return config, diags
+}
+
+// readProfile reads the auth section of profile from file, or from the first default location that exists if file
+// is empty
+func readProfile(file string, profile string) (cloudAuth, error) {
+	if file == "" {
+		for _, candidate := range defaultCredentialsFiles() {
+			if _, err := os.Stat(candidate); err == nil {
+				file = candidate
+				break
+			}
+		}
+		if file == "" {
+			return cloudAuth{}, fmt.Errorf("profile %q is set, but no credentials file was found in %v", profile, defaultCredentialsFiles())
+		}
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return cloudAuth{}, fmt.Errorf("couldn't read credentials file: %w", err)
+	}
+
+	var clouds cloudsFile
+	if err = yaml.Unmarshal(content, &clouds); err != nil {
+		return cloudAuth{}, fmt.Errorf("couldn't parse credentials file %s: %w", file, err)
+	}
+
+	cloud, ok := clouds.Clouds[profile]
+	if !ok {
+		profiles := make([]string, 0, len(clouds.Clouds))
+		for name := range clouds.Clouds {
+			profiles = append(profiles, name)
+		}
+		sort.Strings(profiles)
+		return cloudAuth{}, fmt.Errorf("profile %q not found in credentials file %s, available profiles: %v", profile, file, profiles)
+	}
+	return cloud.Auth, nil
+}
@@ -0,0 +1,261 @@
+package provider_marketplace
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testCloudsYAML = `clouds:
+  marketplace:
+    auth:
+      domain_name: profile-domain
+      username: profile-user
+      password: profile-password
+      totp_secret: JBSWY3DPEHPK3PXP
+  otc:
+    auth:
+      user_domain_name: user-domain
+      username: otc-user
+      password: otc-password
+  both:
+    auth:
+      domain_name: domain
+      user_domain_name: user-domain
+`
+
+// writeCloudsFile writes testCloudsYAML to a temporary file and returns its path
+func writeCloudsFile(t *testing.T) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "clouds.yaml")
+	if err := os.WriteFile(file, []byte(testCloudsYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+// clearCredentialsEnv unsets the environment variables resolveCredentials reads, an empty value counts as unset
+func clearCredentialsEnv(t *testing.T) {
+	for _, env := range []string{envDomainName, envUsername, envPassword, envUserId, envPasscode, envTotpSecret,
+		envSharedCredentialsFile, envProfile} {
+		t.Setenv(env, "")
+	}
+}
+
+func TestResolveCredentials(t *testing.T) {
+	file := writeCloudsFile(t)
+
+	tests := []struct {
+		name   string
+		config marketplaceProvider
+		env    map[string]string
+		// want are the expected values of the login attributes, an empty string means null
+		want        map[string]string
+		wantError   string
+		wantWarning string
+	}{
+		{
+			name: "block overrides env",
+			config: marketplaceProvider{
+				DomainName: types.StringValue("block-domain"),
+				Username:   types.StringValue("block-user"),
+			},
+			env: map[string]string{
+				envDomainName: "env-domain",
+				envUsername:   "env-user",
+				envPassword:   "env-password",
+			},
+			want: map[string]string{"domain_name": "block-domain", "username": "block-user", "password": "env-password"},
+		},
+		{
+			name: "env overrides profile",
+			config: marketplaceProvider{
+				SharedCredentialsFile: types.StringValue(file),
+				Profile:               types.StringValue("marketplace"),
+			},
+			env: map[string]string{envUsername: "env-user"},
+			want: map[string]string{
+				"domain_name": "profile-domain",
+				"username":    "env-user",
+				"password":    "profile-password",
+				"totp_secret": "JBSWY3DPEHPK3PXP",
+			},
+		},
+		{
+			name: "block overrides profile",
+			config: marketplaceProvider{
+				Password:              types.StringValue("block-password"),
+				SharedCredentialsFile: types.StringValue(file),
+				Profile:               types.StringValue("marketplace"),
+			},
+			want: map[string]string{
+				"domain_name": "profile-domain",
+				"username":    "profile-user",
+				"password":    "block-password",
+				"totp_secret": "JBSWY3DPEHPK3PXP",
+			},
+		},
+		{
+			name:   "profile and file from env",
+			config: marketplaceProvider{},
+			env: map[string]string{
+				envSharedCredentialsFile: file,
+				envProfile:               "otc",
+			},
+			want: map[string]string{"domain_name": "user-domain", "username": "otc-user", "password": "otc-password"},
+		},
+		{
+			name: "user_domain_name without domain_name",
+			config: marketplaceProvider{
+				SharedCredentialsFile: types.StringValue(file),
+				Profile:               types.StringValue("otc"),
+			},
+			want: map[string]string{"domain_name": "user-domain", "username": "otc-user", "password": "otc-password"},
+		},
+		{
+			name: "domain_name over user_domain_name",
+			config: marketplaceProvider{
+				SharedCredentialsFile: types.StringValue(file),
+				Profile:               types.StringValue("both"),
+			},
+			want: map[string]string{"domain_name": "domain"},
+		},
+		{
+			name: "unknown profile",
+			config: marketplaceProvider{
+				SharedCredentialsFile: types.StringValue(file),
+				Profile:               types.StringValue("missing"),
+			},
+			wantError: `profile "missing" not found in credentials file`,
+		},
+		{
+			name: "credentials file without profile",
+			config: marketplaceProvider{
+				Username:              types.StringValue("block-user"),
+				SharedCredentialsFile: types.StringValue(file),
+			},
+			want:        map[string]string{"username": "block-user"},
+			wantWarning: "'shared_credentials_file' is only read if 'profile' is set.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearCredentialsEnv(t)
+			for env, value := range tt.env {
+				t.Setenv(env, value)
+			}
+
+			got, diags := resolveCredentials(tt.config)
+
+			checkDiagnostic(t, diags.Errors(), tt.wantError)
+			checkDiagnostic(t, diags.Warnings(), tt.wantWarning)
+			if tt.wantError != "" {
+				return
+			}
+
+			attributes := map[string]types.String{
+				"domain_name": got.DomainName,
+				"username":    got.Username,
+				"password":    got.Password,
+				"user_id":     got.UserId,
+				"passcode":    got.Passcode,
+				"totp_secret": got.TotpSecret,
+			}
+			for name, value := range attributes {
+				if value.ValueString() != tt.want[name] {
+					t.Errorf("%s: expected %q, got %q", name, tt.want[name], value.ValueString())
+				}
+			}
+		})
+	}
+}
+
+// checkDiagnostic expects a single diagnostic whose detail contains want, or none if want is empty
+func checkDiagnostic(t *testing.T, diags diag.Diagnostics, want string) {
+	t.Helper()
+
+	if want == "" {
+		if len(diags) > 0 {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+		return
+	}
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail(), want) {
+		t.Errorf("expected a diagnostic containing %q, got %v", want, diags)
+	}
+}
+
+func TestReadProfile(t *testing.T) {
+	file := writeCloudsFile(t)
+	invalid := filepath.Join(t.TempDir(), "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("clouds: [\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		file      string
+		profile   string
+		want      cloudAuth
+		wantError string
+	}{
+		{
+			name:    "profile",
+			file:    file,
+			profile: "marketplace",
+			want: cloudAuth{
+				DomainName: "profile-domain",
+				Username:   "profile-user",
+				Password:   "profile-password",
+				TotpSecret: "JBSWY3DPEHPK3PXP",
+			},
+		},
+		{
+			name:    "user_domain_name",
+			file:    file,
+			profile: "otc",
+			want:    cloudAuth{UserDomainName: "user-domain", Username: "otc-user", Password: "otc-password"},
+		},
+		{
+			name:      "unknown profile lists the available ones",
+			file:      file,
+			profile:   "missing",
+			wantError: "available profiles: [both marketplace otc]",
+		},
+		{
+			name:      "missing file",
+			file:      filepath.Join(t.TempDir(), "missing.yaml"),
+			profile:   "marketplace",
+			wantError: "couldn't read credentials file",
+		},
+		{
+			name:      "invalid yaml",
+			file:      invalid,
+			profile:   "marketplace",
+			wantError: "couldn't parse credentials file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readProfile(tt.file, tt.profile)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
@@ -1,13 +1,14 @@
 package provider_marketplace
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
-	"net/http"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strconv"
 	"terraform-provider-otc-marketplace/internal/datasource_applications"
 	"terraform-provider-otc-marketplace/internal/datasource_categories"
 	"terraform-provider-otc-marketplace/internal/datasource_clusters"
@@ -18,17 +19,25 @@ import (
 	"terraform-provider-otc-marketplace/internal/datasource_projects"
 	"terraform-provider-otc-marketplace/internal/datasource_sales_history"
 	"terraform-provider-otc-marketplace/internal/datasource_whoami"
+	"terraform-provider-otc-marketplace/internal/ephemeral_token"
+	"terraform-provider-otc-marketplace/internal/functions"
 	"terraform-provider-otc-marketplace/internal/resource_application"
 	"terraform-provider-otc-marketplace/internal/resource_product"
 	"terraform-provider-otc-marketplace/internal/resource_product_revision"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
 	"terraform-provider-otc-marketplace/internal/util"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 )
 
 var _ provider.Provider = (*marketplaceProvider)(nil)
+var _ provider.ProviderWithFunctions = (*marketplaceProvider)(nil)
+var _ provider.ProviderWithEphemeralResources = (*marketplaceProvider)(nil)
 
 func New() func() provider.Provider {
 	return func() provider.Provider {
@@ -37,76 +46,224 @@ func New() func() provider.Provider {
 }
 
 type marketplaceProvider struct {
-	DomainName string `tfsdk:"domain_name"`
-	Username   string `tfsdk:"username"`
-	Password   string `tfsdk:"password"`
+	DomainName types.String `tfsdk:"domain_name"`
+	Username   types.String `tfsdk:"username"`
+	Password   types.String `tfsdk:"password"`
+	UserId     types.String `tfsdk:"user_id"`
+	Passcode   types.String `tfsdk:"passcode"`
+	TotpSecret types.String `tfsdk:"totp_secret"`
+
+	SharedCredentialsFile types.String `tfsdk:"shared_credentials_file"`
+	Profile               types.String `tfsdk:"profile"`
+
+	Endpoint types.String `tfsdk:"endpoint"`
+	CAFile   types.String `tfsdk:"ca_file"`
+	Insecure types.Bool   `tfsdk:"insecure"`
+	ProxyURL types.String `tfsdk:"proxy_url"`
+	Timeout  types.String `tfsdk:"timeout"`
+
+	MaxRetries types.Int64 `tfsdk:"max_retries"`
 }
 
 func (p *marketplaceProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"domain_name": schema.StringAttribute{
-				Required:    true,
-				Description: "The domain name for authentication.",
+				Optional:    true,
+				Description: "The domain name for authentication. Can also be set with `OTC_MARKETPLACE_DOMAIN_NAME`.",
 			},
 			"username": schema.StringAttribute{
-				Required:    true,
-				Description: "The username for authentication.",
+				Optional:    true,
+				Description: "The username for authentication. Required unless MFA is used. Can also be set with `OTC_MARKETPLACE_USERNAME`.",
 			},
 			"password": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password for authentication. Can also be set with `OTC_MARKETPLACE_PASSWORD`.",
+			},
+			"user_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The OTC user id, used instead of `username` when logging in with MFA. Can also be set with `OTC_MARKETPLACE_USER_ID`.",
+			},
+			"passcode": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "A one-time MFA passcode. Conflicts with `totp_secret`. Can also be set with `OTC_MARKETPLACE_PASSCODE`.",
+			},
+			"totp_secret": schema.StringAttribute{
+				Optional:    true,
 				Sensitive:   true,
-				Description: "The password for authentication.",
+				Description: "The base32 encoded seed of the virtual MFA device, used to generate the MFA passcode locally. Conflicts with `passcode`. Can also be set with `OTC_MARKETPLACE_TOTP_SECRET`.",
+			},
+			"shared_credentials_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a clouds.yaml-style credentials file. Defaults to the first of `./clouds.yaml`, `~/.config/openstack/clouds.yaml` and `/etc/openstack/clouds.yaml` that exists. Can also be set with `OTC_MARKETPLACE_SHARED_CREDENTIALS_FILE`.",
+			},
+			"profile": schema.StringAttribute{
+				Optional:    true,
+				Description: "The entry under `clouds` in the credentials file to read credentials from. Values set in the provider block or the environment take precedence over the profile. Can also be set with `OTC_MARKETPLACE_PROFILE`.",
+			},
+			"endpoint": schema.StringAttribute{
+				Optional:    true,
+				Description: "Base URL of the seller API, e.g. of a staging marketplace or a local mock. Defaults to `" + util.DefaultBaseURL + "`. Can also be set with `OTC_MARKETPLACE_ENDPOINT`.",
+			},
+			"ca_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM encoded CA bundle that's trusted in addition to the system CAs. Can also be set with `OTC_MARKETPLACE_CA_FILE`.",
+			},
+			"insecure": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Skip the TLS certificate verification. Only use this for testing. Can also be set with `OTC_MARKETPLACE_INSECURE`.",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the HTTP proxy to use, e.g. `http://proxy.example.com:3128`. Defaults to `HTTPS_PROXY`/`NO_PROXY` from the environment. Can also be set with `OTC_MARKETPLACE_PROXY_URL`.",
+			},
+			"timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "Timeout of a single API request as a duration, e.g. `90s` or `2m`. Defaults to `" + util.DefaultTimeout.String() + "`. Can also be set with `OTC_MARKETPLACE_TIMEOUT`.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "How often requests failing with a network error, 429, 502, 503 or 504 are retried, waiting with an exponential backoff or as long as the `Retry-After` header asks for. Apart from 429, only idempotent requests are retried. `0` disables retries. Defaults to `" + strconv.Itoa(util.DefaultMaxRetries) + "`. Can also be set with `OTC_MARKETPLACE_MAX_RETRIES`.",
 			},
 		},
 	}
 }
 
-func getAuthedMarketplaceClient(ctx context.Context, config marketplaceProvider) (*util.MarketplaceAPIClient, error) {
-	payload := map[string]string{
-		"domain_name": config.DomainName,
-		"username":    config.Username,
-		"password":    config.Password,
+func isSet(v types.String) bool {
+	return !v.IsNull() && !v.IsUnknown() && v.ValueString() != ""
+}
+
+func usesMFA(config marketplaceProvider) bool {
+	return isSet(config.Passcode) || isSet(config.TotpSecret)
+}
+
+// validateLoginConfig checks that the configured attributes describe exactly one of the two login flows of `/login`
+func validateLoginConfig(config marketplaceProvider) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !isSet(config.DomainName) {
+		diags.AddAttributeError(path.Root("domain_name"), "Missing Configuration", fmt.Sprintf("'domain_name' must be provided in the provider block, with %s or in the credentials profile.", envDomainName))
 	}
-	jsonPayload, err := json.Marshal(payload)
-	if err != nil {
-		return nil, err
+	if !isSet(config.Password) {
+		diags.AddAttributeError(path.Root("password"), "Missing Configuration", fmt.Sprintf("'password' must be provided in the provider block, with %s or in the credentials profile.", envPassword))
+	}
+
+	if isSet(config.Passcode) && isSet(config.TotpSecret) {
+		diags.AddAttributeError(path.Root("totp_secret"), "Conflicting Configuration",
+			"Only one of 'passcode' and 'totp_secret' can be provided.")
 	}
 
-	marketplaceClient := util.NewMarketplaceAPIClient()
+	if usesMFA(config) {
+		if !isSet(config.UserId) {
+			diags.AddAttributeError(path.Root("user_id"), "Missing Configuration",
+				"'user_id' must be provided when logging in with MFA ('passcode' or 'totp_secret').")
+		}
+		if isSet(config.Username) {
+			diags.AddAttributeWarning(path.Root("username"), "Ignored Configuration",
+				"'username' is not used when logging in with MFA, 'user_id' is used instead.")
+		}
+	} else {
+		if !isSet(config.Username) {
+			diags.AddAttributeError(path.Root("username"), "Missing Configuration",
+				"'username' must be provided when not logging in with MFA.")
+		}
+		if isSet(config.UserId) {
+			diags.AddAttributeError(path.Root("user_id"), "Incomplete Configuration",
+				"'user_id' is only used when logging in with MFA. Either provide 'passcode' or 'totp_secret', or remove 'user_id'.")
+		}
+	}
 
-	// Make the HTTP request
-	url := fmt.Sprintf("%s/login", marketplaceClient.BaseURL)
-	reqHttp, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return nil, err
+	return diags
+}
+
+// loginPayload builds either the LoginPassword or the Login2FA body for `/login`
+func loginPayload(config marketplaceProvider) (map[string]string, error) {
+	if !usesMFA(config) {
+		return map[string]string{
+			"domain_name": config.DomainName.ValueString(),
+			"username":    config.Username.ValueString(),
+			"password":    config.Password.ValueString(),
+		}, nil
+	}
+
+	passcode := config.Passcode.ValueString()
+	if isSet(config.TotpSecret) {
+		var err error
+		passcode, err = util.GenerateTOTP(config.TotpSecret.ValueString(), time.Now())
+		if err != nil {
+			return nil, err
+		}
 	}
-	reqHttp.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	resHttp, err := client.Do(reqHttp)
+	return map[string]string{
+		"domain_name": config.DomainName.ValueString(),
+		"user_id":     config.UserId.ValueString(),
+		"password":    config.Password.ValueString(),
+		"passcode":    passcode,
+	}, nil
+}
+
+// clientOptions reads the connection settings from the provider block, falling back to the environment
+func clientOptions(config marketplaceProvider) (util.ClientOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	opts := util.ClientOptions{
+		BaseURL:  stringOrEnv(config.Endpoint, envEndpoint).ValueString(),
+		CAFile:   stringOrEnv(config.CAFile, envCAFile).ValueString(),
+		ProxyURL: stringOrEnv(config.ProxyURL, envProxyURL).ValueString(),
+	}
+
+	insecure, err := boolOrEnv(config.Insecure, envInsecure)
 	if err != nil {
-		return nil, err
+		diags.AddAttributeError(path.Root("insecure"), "Invalid Configuration", err.Error())
 	}
-	defer resHttp.Body.Close()
+	opts.Insecure = insecure.ValueBool()
 
-	// Handle response
-	if resHttp.StatusCode != http.StatusOK {
-		return nil, err
+	if timeout := stringOrEnv(config.Timeout, envTimeout); isSet(timeout) {
+		opts.Timeout, err = time.ParseDuration(timeout.ValueString())
+		if err != nil || opts.Timeout <= 0 {
+			diags.AddAttributeError(path.Root("timeout"), "Invalid Configuration",
+				fmt.Sprintf("'timeout' must be a positive duration like \"90s\" or \"2m\", got %q.", timeout.ValueString()))
+		}
 	}
 
-	var response struct {
-		Token string `json:"token"`
+	opts.MaxRetries = util.DefaultMaxRetries
+	maxRetries, err := int64OrEnv(config.MaxRetries, envMaxRetries)
+	if err != nil {
+		diags.AddAttributeError(path.Root("max_retries"), "Invalid Configuration", err.Error())
+	} else if !maxRetries.IsNull() && !maxRetries.IsUnknown() {
+		if maxRetries.ValueInt64() < 0 {
+			diags.AddAttributeError(path.Root("max_retries"), "Invalid Configuration",
+				fmt.Sprintf("'max_retries' can't be negative, got %d.", maxRetries.ValueInt64()))
+		}
+		opts.MaxRetries = int(maxRetries.ValueInt64())
 	}
-	if err = json.NewDecoder(resHttp.Body).Decode(&response); err != nil {
+
+	return opts, diags
+}
+
+func getAuthedMarketplaceClient(ctx context.Context, config marketplaceProvider, opts util.ClientOptions) (*util.MarketplaceAPIClient, error) {
+	passcodeUsed := false
+	marketplaceClient, err := util.NewMarketplaceAPIClient(func() (map[string]string, error) {
+		// A static passcode is only valid once, so the token can't be refreshed with it
+		if isSet(config.Passcode) {
+			if passcodeUsed {
+				return nil, errors.New("the MFA passcode has already been used, configure 'totp_secret' to allow the provider to log in again once the token expires")
+			}
+			passcodeUsed = true
+		}
+		return loginPayload(config)
+	}, opts)
+	if err != nil {
 		return nil, err
 	}
 
-	if response.Token == "" {
-		return nil, errors.New("token is missing from the API response")
+	if err = marketplaceClient.Login(ctx); err != nil {
+		return nil, err
 	}
-	marketplaceClient.Token = response.Token
-	return &marketplaceClient, nil
+	return marketplaceClient, nil
 }
 
 func (p *marketplaceProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
@@ -117,24 +274,40 @@ func (p *marketplaceProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
-	if config.DomainName == "" || config.Username == "" || config.Password == "" {
-		resp.Diagnostics.AddError(
-			"Missing Configuration",
-			"All of 'domain_name', 'username', and 'password' must be provided.",
-		)
+	config, diags = resolveCredentials(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateLoginConfig(config)...)
+	opts, diags := clientOptions(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	marketplaceClient, err := getAuthedMarketplaceClient(ctx, config)
+	marketplaceClient, err := getAuthedMarketplaceClient(ctx, config, opts)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Couldn't authenticate",
 			fmt.Sprintf("Couldn't get instance of marketplaceClient: %s", err.Error()),
 		)
+		return
 	}
 
-	resp.DataSourceData = marketplaceClient
-	resp.ResourceData = marketplaceClient
+	sellerClient := sellerapi.NewClient(marketplaceClient)
+	resp.DataSourceData = sellerClient
+	resp.ResourceData = sellerClient
+	resp.EphemeralResourceData = &ephemeral_token.TokenSource{
+		Login: func(ctx context.Context) (*util.MarketplaceAPIClient, error) {
+			if isSet(config.Passcode) {
+				return nil, errors.New("the MFA passcode was already used by the provider, configure 'totp_secret' to log in for a separate token")
+			}
+			return getAuthedMarketplaceClient(ctx, config, opts)
+		},
+		Options: opts,
+	}
 }
 
 func (p *marketplaceProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -144,12 +317,17 @@ func (p *marketplaceProvider) Metadata(ctx context.Context, req provider.Metadat
 func (p *marketplaceProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		datasource_whoami.NewWhoamiDataSource,
+		datasource_categories.NewCategoriesDataSource,
 		datasource_categories.NewCategoryDataSource,
+		datasource_clusters.NewClustersDataSource,
 		datasource_clusters.NewClusterDataSource,
 		datasource_namespaces.NewNamespaceDataSource,
+		datasource_projects.NewProjectsDataSource,
 		datasource_projects.NewProjectDataSource,
 		datasource_sales_history.NewSalesHistoryDataSource,
+		datasource_products.NewProductsDataSource,
 		datasource_products.NewProductDataSource,
+		datasource_product_revisions.NewProductRevisionsDataSource,
 		datasource_product_revisions.NewProductRevisionDataSource,
 		datasource_applications.NewApplicationDataSource,
 		datasource_profile.NewProfileDataSource,
@@ -161,5 +339,22 @@ func (p *marketplaceProvider) Resources(ctx context.Context) []func() resource.R
 		resource_application.NewApplicationResource,
 		resource_product.NewProductResource,
 		resource_product_revision.NewProductRevisionResource,
+		resource_product_revision.NewProductRevisionSubmissionResource,
+	}
+}
+
+func (p *marketplaceProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		ephemeral_token.NewTokenEphemeralResource,
+	}
+}
+
+// Functions don't use the API, so they work without credentials
+func (p *marketplaceProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewIconDataUriFunction,
+		functions.NewValidateNanoidFunction,
+		functions.NewConfigTemplateFunction,
+		functions.NewParseHelmExternalFunction,
 	}
 }
@@ -0,0 +1,110 @@
+package provider_marketplace_test
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"regexp"
+	"terraform-provider-otc-marketplace/internal/acctest"
+	"terraform-provider-otc-marketplace/internal/testserver"
+	"testing"
+)
+
+func TestProviderLogin(t *testing.T) {
+	server := acctest.NewServer(t, testserver.Options{})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig(server) + `
+data "otc-marketplace_whoami" "me" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.otc-marketplace_whoami.me", "domain_name", acctest.DomainName),
+					resource.TestCheckResourceAttr("data.otc-marketplace_whoami.me", "username", acctest.Username),
+				),
+			},
+		},
+	})
+}
+
+func TestProviderLoginTOTP(t *testing.T) {
+	const totpSecret = "JBSWY3DPEHPK3PXP"
+	server := acctest.NewServer(t, testserver.Options{UserId: "user-id", TotpSecret: totpSecret})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "otc-marketplace" {
+  endpoint    = %q
+  domain_name = %q
+  user_id     = "user-id"
+  password    = %q
+  totp_secret = %q
+}
+
+data "otc-marketplace_whoami" "me" {}
+`, server.BaseURL(), acctest.DomainName, acctest.Password, totpSecret),
+				Check: resource.TestCheckResourceAttr("data.otc-marketplace_whoami.me", "domain_name", acctest.DomainName),
+			},
+		},
+	})
+}
+
+func TestProviderInvalidCredentials(t *testing.T) {
+	server := acctest.NewServer(t, testserver.Options{})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "otc-marketplace" {
+  endpoint    = %q
+  domain_name = %q
+  username    = %q
+  password    = "wrong"
+  max_retries = 0
+}
+
+data "otc-marketplace_whoami" "me" {}
+`, server.BaseURL(), acctest.DomainName, acctest.Username),
+				ExpectError: regexp.MustCompile(`Couldn't authenticate`),
+			},
+		},
+	})
+}
+
+func TestProviderRetriesUnavailableBackend(t *testing.T) {
+	server := acctest.NewServer(t, testserver.Options{})
+	// Retry-After keeps the test from waiting for the backoff
+	server.InjectFault(testserver.Fault{
+		Method:     "GET",
+		Path:       "/whoami",
+		Times:      2,
+		StatusCode: 503,
+		Header:     map[string][]string{"Retry-After": {"0"}},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "otc-marketplace" {
+  endpoint    = %q
+  domain_name = %q
+  username    = %q
+  password    = %q
+  max_retries = 2
+}
+
+data "otc-marketplace_whoami" "me" {}
+`, server.BaseURL(), acctest.DomainName, acctest.Username, acctest.Password),
+				Check: resource.TestCheckResourceAttr("data.otc-marketplace_whoami.me", "username", acctest.Username),
+			},
+		},
+	})
+}
@@ -0,0 +1,74 @@
+package resource_application
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sort"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+	"terraform-provider-otc-marketplace/internal/util"
+)
+
+var confidentialConfigurationAttribute = schema.MapAttribute{
+	ElementType: types.StringType,
+	Optional:    true,
+	Sensitive:   true,
+	Description: "Configuration values that are hidden in the plan and logs, by key. Meant for keys the configuration " +
+		"template marks as `confidential`. They're sent together with `configuration`.",
+}
+
+// confidentialConfiguration returns the confidential configuration sorted by key, so it's sent in a stable order
+func (m applicationResourceModel) confidentialConfiguration(ctx context.Context) ([]sellerapi.ApplicationConfiguration, diag.Diagnostics) {
+	if m.ConfidentialConfiguration.IsNull() || m.ConfidentialConfiguration.IsUnknown() {
+		return nil, nil
+	}
+
+	values := map[string]string{}
+	diags := m.ConfidentialConfiguration.ElementsAs(ctx, &values, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	configuration := make([]sellerapi.ApplicationConfiguration, 0, len(values))
+	for key, value := range values {
+		configuration = append(configuration, sellerapi.ApplicationConfiguration{Key: key, Value: value})
+	}
+	sort.Slice(configuration, func(i, j int) bool {
+		return configuration[i].Key < configuration[j].Key
+	})
+	return configuration, diags
+}
+
+// withSensitiveValues keeps the confidential values out of the logs and errors of the requests sent with the context
+func (m applicationResourceModel) withSensitiveValues(ctx context.Context) context.Context {
+	configuration, _ := m.confidentialConfiguration(ctx)
+	values := make([]string, 0, len(configuration))
+	for _, entry := range configuration {
+		values = append(values, entry.Value)
+	}
+	return util.WithSensitiveValues(ctx, values...)
+}
+
+// withoutConfidentialConfiguration removes the confidential keys from the configuration the marketplace returned,
+// their values are kept in the state as they were planned
+func (m applicationResourceModel) withoutConfidentialConfiguration(ctx context.Context, configuration types.List) (types.List, diag.Diagnostics) {
+	if m.ConfidentialConfiguration.IsNull() || m.ConfidentialConfiguration.IsUnknown() || configuration.IsNull() {
+		return configuration, nil
+	}
+
+	var entries []ConfigurationValue
+	diags := configuration.ElementsAs(ctx, &entries, false)
+	if diags.HasError() {
+		return configuration, diags
+	}
+
+	confidential := m.ConfidentialConfiguration.Elements()
+	var public []ConfigurationValue
+	for _, entry := range entries {
+		if _, ok := confidential[entry.Key.ValueString()]; !ok {
+			public = append(public, entry)
+		}
+	}
+	return util.ListValueOrNull[ConfigurationValue](ctx, ConfigurationValue{}.Type(ctx), public, &diags), diags
+}
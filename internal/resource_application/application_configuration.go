@@ -0,0 +1,176 @@
+package resource_application
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+	"terraform-provider-otc-marketplace/internal/util"
+)
+
+var _ resource.ResourceWithModifyPlan = (*applicationResource)(nil)
+
+// ModifyPlan validates the configuration against the configuration template of the product revision, so mistakes show
+// up in the plan instead of as a failed deployment
+func (r *applicationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate when destroying, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var planned applicationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planned)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if planned.ProductRevisionId.IsUnknown() || planned.Configuration.IsUnknown() {
+		return
+	}
+
+	// Only fetch the template if the revision or configuration change
+	if !req.State.Raw.IsNull() {
+		var prior applicationResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if prior.ProductRevisionId.Equal(planned.ProductRevisionId) && prior.Configuration.Equal(planned.Configuration) &&
+			prior.ConfidentialConfiguration.Equal(planned.ConfidentialConfiguration) {
+			return
+		}
+	}
+
+	var configuration []ConfigurationValue
+	if !planned.Configuration.IsNull() {
+		resp.Diagnostics.Append(planned.Configuration.ElementsAs(ctx, &configuration, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	entries := make([]configurationEntry, 0, len(configuration))
+	for i, entry := range configuration {
+		entries = append(entries, configurationEntry{
+			key:       entry.Key,
+			value:     entry.Value,
+			keyPath:   path.Root("configuration").AtListIndex(i).AtName("key"),
+			valuePath: path.Root("configuration").AtListIndex(i).AtName("value"),
+		})
+	}
+	if !planned.ConfidentialConfiguration.IsUnknown() {
+		confidential := map[string]types.String{}
+		resp.Diagnostics.Append(planned.ConfidentialConfiguration.ElementsAs(ctx, &confidential, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for key, value := range confidential {
+			entryPath := path.Root("confidential_configuration").AtMapKey(key)
+			entries = append(entries, configurationEntry{
+				key:          types.StringValue(key),
+				value:        value,
+				keyPath:      entryPath,
+				valuePath:    entryPath,
+				confidential: true,
+			})
+		}
+	}
+
+	id := planned.ProductRevisionId.ValueString()
+	revision, err := r.client.GetRevision(ctx, util.SanitizeString(id))
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			fmt.Sprintf("Couldn't validate the configuration against product revision %s", id),
+			fmt.Sprintf("The configuration is sent as it is.\nerror: %v", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(validateConfiguration(revision.Configuration, entries)...)
+}
+
+// configurationEntry is a configuration value from either `configuration` or `confidential_configuration`
+type configurationEntry struct {
+	key          types.String
+	value        types.String
+	keyPath      path.Path
+	valuePath    path.Path
+	confidential bool
+}
+
+// validateConfiguration reports missing required keys, unknown keys and values the template doesn't allow. Values
+// that are only known after apply are skipped.
+func validateConfiguration(templates []sellerapi.ConfigurationTemplate, configuration []configurationEntry) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	byKey := map[string]sellerapi.ConfigurationTemplate{}
+	for _, template := range templates {
+		byKey[template.Key] = template
+	}
+
+	set := map[string]bool{}
+	unknownKeys := false
+	for _, entry := range configuration {
+		if entry.key.IsUnknown() {
+			unknownKeys = true
+			continue
+		}
+		key := entry.key.ValueString()
+		set[key] = true
+
+		template, ok := byKey[key]
+		if !ok {
+			diags.AddAttributeError(
+				entry.keyPath,
+				"Unknown configuration key",
+				fmt.Sprintf("The product revision has no configuration %q, it has: %s.", key, strings.Join(templateKeys(templates), ", ")),
+			)
+			continue
+		}
+		if template.Confidential && !entry.confidential {
+			diags.AddAttributeWarning(
+				entry.keyPath,
+				"Confidential configuration in plain text",
+				fmt.Sprintf("The product revision marks %q as confidential, its value is shown in the plan and logs. "+
+					"Set it in confidential_configuration instead.", key),
+			)
+		}
+		if entry.value.IsUnknown() || entry.value.IsNull() {
+			continue
+		}
+
+		for _, problem := range template.ValidateValue(entry.value.ValueString()) {
+			if entry.confidential {
+				problem = strings.ReplaceAll(problem, entry.value.ValueString(), util.Redacted)
+			}
+			diags.AddAttributeError(entry.valuePath, fmt.Sprintf("Invalid value for %s", key), problem)
+		}
+	}
+
+	// A required configuration with a default value is filled in by the marketplace. Keys only known after apply may
+	// be the missing ones.
+	if unknownKeys {
+		return diags
+	}
+	for _, template := range templates {
+		if template.Required && template.DefaultValue == "" && !set[template.Key] {
+			diags.AddAttributeError(
+				path.Root("configuration"),
+				"Missing required configuration",
+				fmt.Sprintf("The product revision requires a value for %q.", template.Key),
+			)
+		}
+	}
+
+	return diags
+}
+
+func templateKeys(templates []sellerapi.ConfigurationTemplate) []string {
+	keys := make([]string, 0, len(templates))
+	for _, template := range templates {
+		keys = append(keys, template.Key)
+	}
+	return keys
+}
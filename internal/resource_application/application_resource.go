@@ -1,33 +1,31 @@
 package resource_application
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"net/http"
-	"terraform-provider-otc-marketplace/internal/datasource_applications"
 	"terraform-provider-otc-marketplace/internal/resource_product"
 	"terraform-provider-otc-marketplace/internal/resource_product_revision"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
 	"terraform-provider-otc-marketplace/internal/util"
 )
 
 var _ resource.Resource = (*applicationResource)(nil)
+var _ resource.ResourceWithImportState = (*applicationResource)(nil)
 
 func NewApplicationResource() resource.Resource {
 	return &applicationResource{}
 }
 
-const applicationResourcePath = "/applications"
-
 type applicationResource struct {
-	client *util.MarketplaceAPIClient
+	client *sellerapi.Client
 }
 
 func (r *applicationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -35,7 +33,20 @@ func (r *applicationResource) Metadata(ctx context.Context, req resource.Metadat
 }
 
 func (r *applicationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = ApplicationResourceSchema(ctx)
+	s, diags := applicationSchema(ctx)
+	resp.Diagnostics.Append(diags...)
+	resp.Schema = s
+	if resp.Schema.Blocks == nil {
+		resp.Schema.Blocks = map[string]schema.Block{}
+	}
+	resp.Schema.Blocks["timeouts"] = timeouts.Block(ctx, timeouts.Opts{
+		Create:            true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: fmt.Sprintf("How long to wait for the deployment to become `ready`. Defaults to `%s`.", defaultApplicationCreateTimeout),
+		UpdateDescription: fmt.Sprintf("How long to wait for the upgraded deployment to become `ready`, including the removal of the old one. Defaults to `%s`.", defaultApplicationUpdateTimeout),
+		DeleteDescription: fmt.Sprintf("How long to wait for the deployment to be removed, so the release name can be used again. Defaults to `%s`.", defaultApplicationDeleteTimeout),
+	})
 }
 
 func (r *applicationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -45,7 +56,7 @@ func (r *applicationResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	clientPTR, ok := req.ProviderData.(*util.MarketplaceAPIClient)
+	clientPTR, ok := req.ProviderData.(*sellerapi.Client)
 	if !ok || clientPTR == nil {
 		resp.Diagnostics.AddError(
 			"Provider Configuration Error",
@@ -56,63 +67,32 @@ func (r *applicationResource) Configure(ctx context.Context, req resource.Config
 	r.client = clientPTR
 }
 
-// TODO - Can Read (GET (both), can't POST, can't DELETE)
-
-type ApplicationNativeModel struct {
-	ByolLicense       string                                                       `json:"byol_license,omitempty" tfsdk:"byol_license"`
-	ClusterId         string                                                       `json:"cluster_id,omitempty" tfsdk:"cluster_id"`
-	Configuration     []datasource_applications.ConfigurationNativeModel           `json:"configuration,omitempty" tfsdk:"configuration"` // TODO - naming
-	CreatedAt         string                                                       `json:"created_at,omitempty" tfsdk:"created_at"`
-	Description       string                                                       `json:"description,omitempty" tfsdk:"description"`
-	Id                string                                                       `json:"id" tfsdk:"id"`
-	Namespace         string                                                       `json:"namespace,omitempty" tfsdk:"namespace"`
-	Product           util.ProductDataSourceNativeModel                            `json:"product,omitempty" tfsdk:"product"` // TODO - naming
-	ProductRevision   resource_product_revision.ProductRevisionResourceNativeModel `json:"product_revision,omitempty" tfsdk:"product_revision"`
-	ProductRevisionId string                                                       `json:"product_revision_id,omitempty" tfsdk:"product_revision_id"`
-	ProjectId         string                                                       `json:"project_id,omitempty" tfsdk:"project_id"`
-	ReleaseName       string                                                       `json:"release_name,omitempty" tfsdk:"release_name"`
-	Seller            util.SellerNativeModel                                       `json:"seller,omitempty" tfsdk:"seller"`
-	State             string                                                       `json:"state,omitempty" tfsdk:"state"`
-	Username          string                                                       `json:"username,omitempty" tfsdk:"username"`
+// ImportState imports an existing application by its NanoID, e.g. one created in the seller dashboard
+func (r *applicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	util.ImportStateByNanoID(ctx, req, resp)
 }
 
-type ApplicationResourceModNativeModel struct {
-	// Required
-	ProductRevisionId string `json:"product_revision_id,omitempty" tfsdk:"product_revision_id"`
-	ProjectId         string `json:"project_id,omitempty" tfsdk:"project_id"`
-	ClusterId         string `json:"cluster_id,omitempty" tfsdk:"cluster_id"`
-	Namespace         string `json:"namespace,omitempty" tfsdk:"namespace"`
-	// Optional
-	ReleaseName   string                                             `json:"release_name,omitempty" tfsdk:"release_name"`
-	Description   string                                             `json:"description,omitempty" tfsdk:"description"`
-	State         string                                             `json:"state,omitempty" tfsdk:"state"`
-	Username      string                                             `json:"username,omitempty" tfsdk:"username"`
-	ByolLicense   string                                             `json:"byol_license,omitempty" tfsdk:"byol_license"`
-	Configuration []datasource_applications.ConfigurationNativeModel `json:"application_configuration,omitempty" tfsdk:"application_configuration"`
-}
+// TODO - Can Read (GET (both), can't POST, can't DELETE)
 
-func applicationResourceModMapper(ctx context.Context, data ApplicationModel) ([]byte, error) {
-	var tempConfigs []datasource_applications.ConfigurationNativeModel
+// applicationResourceModMapper returns the application to deploy for the plan
+func applicationResourceModMapper(ctx context.Context, data ApplicationModel) (*sellerapi.ApplicationInput, error) {
+	var tempConfigs []sellerapi.ApplicationConfiguration
 	if !data.Configuration.IsUnknown() {
 		diags := data.Configuration.ElementsAs(ctx, &tempConfigs, false)
 		if diags.HasError() {
 			return nil, errors.New(fmt.Sprintf("couldn't convert data categories to list of str: elements: %v, tempCategories: %v, err: %+v", data.Configuration.Elements(), tempConfigs, diags.Errors()))
 		}
 	}
-	body, err := json.Marshal(ApplicationResourceModNativeModel{
+	return &sellerapi.ApplicationInput{
 		ProductRevisionId: data.ProductRevisionId.ValueString(),
 		ProjectId:         data.ProjectId.ValueString(),
 		ClusterId:         data.ClusterId.ValueString(),
 		Namespace:         data.Namespace.ValueString(),
 		ReleaseName:       data.ReleaseName.ValueString(),
 		Description:       data.Description.ValueString(),
-		State:             data.State.ValueString(),
-		Username:          data.Username.ValueString(),
 		ByolLicense:       data.ByolLicense.ValueString(),
 		Configuration:     tempConfigs,
-	})
-
-	return body, err
+	}, nil
 }
 
 // TODO - this is dumb, a much cleaner way exists
@@ -177,7 +157,7 @@ func productRevisionModeltoApplicationProductRevision(ctx context.Context, in re
 	}, nil
 }
 
-func applicationResourceMapper(ctx context.Context, newDataPTR *ApplicationNativeModel) (*ApplicationModel, error) {
+func applicationResourceMapper(ctx context.Context, newDataPTR *sellerapi.Application) (*ApplicationModel, error) {
 	var diags diag.Diagnostics
 
 	productPMObj, err := resource_product.ProductResourceMapper(ctx, &newDataPTR.Product)
@@ -204,7 +184,7 @@ func applicationResourceMapper(ctx context.Context, newDataPTR *ApplicationNativ
 		Description:  util.StringSetOrNull(newDataPTR.Seller.Description),
 		Id:           util.StringSetOrNull(newDataPTR.Seller.Id),
 		Name:         util.StringSetOrNull(newDataPTR.Seller.Name),
-		State:        util.StringSetOrNull(newDataPTR.Seller.State),
+		State:        util.StringSetOrNull(string(newDataPTR.Seller.State)),
 		SupportEmail: util.StringSetOrNull(newDataPTR.Seller.SupportEmail),
 		SupportUrl:   util.StringSetOrNull(newDataPTR.Seller.SupportUrl),
 	}
@@ -223,6 +203,12 @@ func applicationResourceMapper(ctx context.Context, newDataPTR *ApplicationNativ
 		return nil, errors.New(fmt.Sprintf("error: %v", diags.Errors()))
 	}
 
+	// Not every response repeats product_revision_id, e.g. after an import it's only known from the nested revision
+	productRevisionId := newDataPTR.ProductRevisionId
+	if productRevisionId == "" {
+		productRevisionId = newDataPTR.ProductRevision.Id
+	}
+
 	return &ApplicationModel{
 		ByolLicense:       util.StringSetOrNull(newDataPTR.ByolLicense),
 		ClusterId:         util.StringSetOrNull(newDataPTR.ClusterId),
@@ -233,17 +219,17 @@ func applicationResourceMapper(ctx context.Context, newDataPTR *ApplicationNativ
 		Namespace:         util.StringSetOrNull(newDataPTR.Namespace),
 		Product:           *productObj,
 		ProductRevision:   *productRevisionObj,
-		ProductRevisionId: util.StringSetOrNull(newDataPTR.ProductRevisionId),
+		ProductRevisionId: util.StringSetOrNull(productRevisionId),
 		ProjectId:         util.StringSetOrNull(newDataPTR.ProjectId),
 		ReleaseName:       util.StringSetOrNull(newDataPTR.ReleaseName),
 		ApplicationSeller: sellerObj,
-		State:             util.StringSetOrNull(newDataPTR.State),
+		State:             util.StringSetOrNull(string(newDataPTR.State)),
 		Username:          util.StringSetOrNull(newDataPTR.Username),
 	}, nil
 }
 
 func (r *applicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data ApplicationModel
+	var data applicationResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -276,25 +262,23 @@ func (r *applicationResource) Create(ctx context.Context, req resource.CreateReq
 			"namespace needs to be set", "namespace is either null or unknown")
 		return
 	}
-	// Example data value setting
-	// data.Id = types.StringValue("example-id")
-
-	body, err := applicationResourceModMapper(ctx, data)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Couldn't map plan data into ApplicationMod struct", fmt.Sprintf("err: %v", err))
+	ctx = data.withSensitiveValues(ctx)
+	newProductPTR := r.createApplication(ctx, data, &resp.Diagnostics)
+	if newProductPTR == nil {
 		return
 	}
 
-	newProductPTR, err := util.MakeMarketplaceRequest[ApplicationNativeModel](ctx, http.MethodPost, applicationResourcePath, bytes.NewReader(body), r.client)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %s", http.MethodPost, applicationResourcePath, body),
-			fmt.Sprintf("error: %v", err),
-		)
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultApplicationCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	readyPTR, waitErr := r.waitForApplicationReady(ctx, newProductPTR.Id, createTimeout)
+	if readyPTR != nil {
+		newProductPTR = readyPTR
+	}
+
 	dataPTR, err := applicationResourceMapper(ctx, newProductPTR)
 	if err != nil {
 		resp.Diagnostics.AddError("Couldn't map response to product resource", fmt.Sprintf("error: %v", err))
@@ -303,13 +287,21 @@ func (r *applicationResource) Create(ctx context.Context, req resource.CreateReq
 
 	dataPTR.ProductRevisionId = data.ProductRevisionId
 
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &dataPTR)...)
-	tflog.Warn(ctx, fmt.Sprintf("Read required after Create. Run `terraform apply -refresh-only` now."))
+	dataPTR.Configuration, diags = data.withoutConfidentialConfiguration(ctx, dataPTR.Configuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state, even if the deployment failed, so the application is tainted and replaced
+	resp.Diagnostics.Append(resp.State.Set(ctx, &applicationResourceModel{ApplicationModel: *dataPTR, ConfidentialConfiguration: data.ConfidentialConfiguration, Timeouts: data.Timeouts})...)
+	if waitErr != nil {
+		resp.Diagnostics.AddError("Application deployment didn't become ready", waitErr.Error())
+	}
 }
 
 func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data ApplicationModel
+	var data applicationResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -320,11 +312,16 @@ func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	url := fmt.Sprintf("%s/%s", applicationResourcePath, util.SanitizeString(data.Id.ValueString()))
-	newDataNativePTR, err := util.MakeMarketplaceRequest[ApplicationNativeModel](ctx, http.MethodGet, url, nil, r.client)
+	ctx = data.withSensitiveValues(ctx)
+	newDataNativePTR, err := r.client.GetApplication(ctx, util.SanitizeString(data.Id.ValueString()))
+	if util.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("application %s no longer exists, removing it from the state", data.Id.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %v", http.MethodGet, url, nil),
+			fmt.Sprintf("Couldn't read application %s", data.Id.ValueString()),
 			fmt.Sprintf("error: %v", err),
 		)
 		return
@@ -336,23 +333,30 @@ func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	// Confidential values aren't compared with the marketplace, the planned ones are kept
+	configuration, diags := data.withoutConfidentialConfiguration(ctx, dataPTR.Configuration)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	// Read API call logic
+	dataPTR.Configuration = configuration
 
 	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, dataPTR)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &applicationResourceModel{ApplicationModel: *dataPTR, ConfidentialConfiguration: data.ConfidentialConfiguration, Timeouts: data.Timeouts})...)
 }
 
-// TODO - the openapi yaml doesn't define any Update (Patch) methods, so this might just not be implemented on the backend
+// Update upgrades the application, the marketplace can't change a deployed application in place
 func (r *applicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ApplicationModel
+	var data applicationResourceModel
+	var priorState applicationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
+	data.Id = priorState.Id
+
 	if data.ProductRevisionId.IsNull() || data.ProductRevisionId.IsUnknown() {
 		resp.Diagnostics.AddError(
 			"product_revision_id needs to be set", "product_revision_id is either null or unknown")
@@ -381,22 +385,34 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	// Update API call logic
-
-	body, err := applicationResourceModMapper(ctx, data)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Couldn't map plan data into ApplicationMod struct", fmt.Sprintf("err: %v", err))
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultApplicationUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	newProductPTR, err := util.MakeMarketplaceRequest[ApplicationNativeModel](ctx, http.MethodPatch, applicationResourcePath, bytes.NewReader(body), r.client)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %s", http.MethodPatch, applicationResourcePath, body),
-			fmt.Sprintf("error: %v", err),
-		)
-		return
+	ctx = priorState.withSensitiveValues(data.withSensitiveValues(ctx))
+	var newProductPTR *sellerapi.Application
+	var waitErr error
+	if needsUpgrade(priorState, data) {
+		newProductPTR, waitErr = r.upgradeApplication(ctx, priorState, data, updateTimeout, &resp.Diagnostics)
+		if newProductPTR == nil {
+			if waitErr != nil {
+				resp.Diagnostics.AddError("Application deployment didn't become ready", waitErr.Error())
+			}
+			return
+		}
+	} else {
+		// Only the timeouts changed, the application is read again as the computed attributes are unknown in the plan
+		var err error
+		newProductPTR, err = r.client.GetApplication(ctx, util.SanitizeString(priorState.Id.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Couldn't read application %s", priorState.Id.ValueString()),
+				fmt.Sprintf("error: %v", err),
+			)
+			return
+		}
 	}
 
 	dataPTR, err := applicationResourceMapper(ctx, newProductPTR)
@@ -405,22 +421,35 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	dataPTR.Configuration, diags = data.withoutConfidentialConfiguration(ctx, dataPTR.Configuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, dataPTR)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &applicationResourceModel{ApplicationModel: *dataPTR, ConfidentialConfiguration: data.ConfidentialConfiguration, Timeouts: data.Timeouts})...)
+	if waitErr != nil {
+		resp.Diagnostics.AddError("Application deployment didn't become ready", waitErr.Error())
+	}
 }
 
 func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data ApplicationModel
+	var data applicationResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
-	url := fmt.Sprintf("%s/%s", applicationResourcePath, util.SanitizeString(data.Id.ValueString()))
-	_, err := util.MakeMarketplaceRequest[struct{}](ctx, http.MethodDelete, url, nil, r.client)
+	err := r.client.DeleteApplication(ctx, util.SanitizeString(data.Id.ValueString()))
+	if util.IsNotFound(err) {
+		// Already gone, e.g. deleted in the seller dashboard
+		tflog.Info(ctx, fmt.Sprintf("application %s was already deleted", data.Id.ValueString()))
+		err = nil
+	}
 	if err != nil {
 		// TODO - 500s when trying to delete an Application with the install still visible on https://marketplace.otc.t-systems.com/dashboard -> Test Deployment Workload
 		resp.Diagnostics.AddWarning(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %v", http.MethodDelete, url, nil),
+			fmt.Sprintf("Couldn't delete application %s", data.Id.ValueString()),
 			fmt.Sprintf("error: %+v\n ignoring error and carrying on...", err),
 		)
 		//return
@@ -430,5 +459,19 @@ func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	// The release can only be deployed again once helm removed it
+	if err == nil {
+		deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultApplicationDeleteTimeout)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if err := r.waitForApplicationDeleted(ctx, data.Id.ValueString(), deleteTimeout); err != nil {
+			resp.Diagnostics.AddError("Application deployment wasn't removed", err.Error())
+			return
+		}
+	}
+
 	resp.State.RemoveResource(ctx)
 }
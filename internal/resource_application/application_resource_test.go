@@ -0,0 +1,179 @@
+package resource_application_test
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"regexp"
+	"strings"
+	"terraform-provider-otc-marketplace/internal/acctest"
+	"terraform-provider-otc-marketplace/internal/testserver"
+	"testing"
+)
+
+const applicationAddress = "otc-marketplace_application.test"
+
+// seedRevisions seeds a product with two revisions that take a `replicas` value, and returns the revision ids
+func seedRevisions(server *testserver.Server) (string, string) {
+	productId := testserver.NewNanoID()
+	server.Seed(testserver.Products, testserver.Object{"id": productId, "name": "Prometheus exporter", "type": "k8s", "license_type": "free"})
+
+	template := []testserver.Object{{"key": "replicas", "label": "Replicas", "input_type": "text"}}
+	first, second := testserver.NewNanoID(), testserver.NewNanoID()
+	server.Seed(testserver.ProductRevisions,
+		testserver.Object{"id": first, "product_id": productId, "version": "1.0.0", "state": "approved", "configuration": template},
+		testserver.Object{"id": second, "product_id": productId, "version": "1.1.0", "state": "approved", "configuration": template},
+	)
+	return first, second
+}
+
+func applicationConfig(server *testserver.Server, revisionId string, replicas string, extra string) string {
+	return acctest.ProviderConfig(server) + fmt.Sprintf(`
+resource "otc-marketplace_application" "test" {
+  product_revision_id = %q
+  project_id          = "project"
+  cluster_id          = "cluster"
+  namespace           = "monitoring"
+  configuration       = [{ key = "replicas", value = %q }]
+%s
+}
+`, revisionId, replicas, extra)
+}
+
+// saveId stores the id of the application, to compare it in later steps
+func saveId(id *string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		*id = state.RootModule().Resources[applicationAddress].Primary.ID
+		return nil
+	}
+}
+
+// saveAttr stores an attribute of the application, to compare it in later steps
+func saveAttr(name string, value *string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		*value = state.RootModule().Resources[applicationAddress].Primary.Attributes[name]
+		return nil
+	}
+}
+
+// checkUpgraded checks the application was redeployed under the same release with the given revision and replicas
+func checkUpgraded(server *testserver.Server, previousId *string, releaseName *string, revisionId string, replicas string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		applications := server.List(testserver.Applications)
+		if len(applications) != 1 {
+			return fmt.Errorf("expected one application, got %v", applications)
+		}
+		application := applications[0]
+		if application["id"] == *previousId || application["release_name"] != *releaseName || application["product_revision_id"] != revisionId {
+			return fmt.Errorf("expected application %s to be redeployed as release %s with revision %s, got %v", *previousId, *releaseName, revisionId, application)
+		}
+		if configuration := fmt.Sprint(application["configuration"]); configuration != fmt.Sprintf("[map[key:replicas value:%s]]", replicas) {
+			return fmt.Errorf("expected replicas %s, got %s", replicas, configuration)
+		}
+		return nil
+	}
+}
+
+func TestApplicationResource(t *testing.T) {
+	server := acctest.NewServer(t, testserver.Options{})
+	first, second := seedRevisions(server)
+
+	var id, releaseName string
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if applications := server.List(testserver.Applications); len(applications) > 0 {
+				return fmt.Errorf("expected the application to be deleted, got %v", applications)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: applicationConfig(server, first, "1", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(applicationAddress, "state", "ready"),
+					resource.TestCheckResourceAttr(applicationAddress, "product_revision_id", first),
+					resource.TestCheckResourceAttrSet(applicationAddress, "release_name"),
+					saveId(&id),
+					saveAttr("release_name", &releaseName),
+				),
+			},
+			{
+				// Only stored in the state, so the application isn't redeployed
+				Config: applicationConfig(server, first, "1", `  timeouts { create = "45m" }`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectResourceAction(applicationAddress, plancheck.ResourceActionUpdate)},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(applicationAddress, "timeouts.create", "45m"),
+					resource.TestCheckResourceAttrPtr(applicationAddress, "id", &id),
+					resource.TestCheckResourceAttr(applicationAddress, "state", "ready"),
+				),
+			},
+			{
+				Config: applicationConfig(server, second, "1", `  timeouts { create = "45m" }`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectResourceAction(applicationAddress, plancheck.ResourceActionUpdate)},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(applicationAddress, "product_revision_id", second),
+					resource.TestCheckResourceAttr(applicationAddress, "state", "ready"),
+					resource.TestCheckResourceAttrPtr(applicationAddress, "release_name", &releaseName),
+					checkUpgraded(server, &id, &releaseName, second, "1"),
+					saveId(&id),
+				),
+			},
+			{
+				Config: applicationConfig(server, second, "2", `  timeouts { create = "45m" }`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectResourceAction(applicationAddress, plancheck.ResourceActionUpdate)},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(applicationAddress, "configuration.0.value", "2"),
+					resource.TestCheckResourceAttrPtr(applicationAddress, "release_name", &releaseName),
+					checkUpgraded(server, &id, &releaseName, second, "2"),
+				),
+			},
+			{
+				// The deployment target can't be changed by an upgrade
+				Config: strings.Replace(applicationConfig(server, second, "2", `  timeouts { create = "45m" }`), `"monitoring"`, `"observability"`, 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectResourceAction(applicationAddress, plancheck.ResourceActionDestroyBeforeCreate)},
+				},
+				Check: resource.TestCheckResourceAttr(applicationAddress, "namespace", "observability"),
+			},
+		},
+	})
+}
+
+func TestApplicationResourceWaitsForDeployment(t *testing.T) {
+	// Pending on the first poll, so ready means it was polled again
+	server := acctest.NewServer(t, testserver.Options{PendingPolls: 1})
+	first, _ := seedRevisions(server)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: applicationConfig(server, first, "1", ""),
+				Check:  resource.TestCheckResourceAttr(applicationAddress, "state", "ready"),
+			},
+		},
+	})
+}
+
+func TestApplicationResourceFailedDeployment(t *testing.T) {
+	server := acctest.NewServer(t, testserver.Options{FailDeployments: true})
+	first, _ := seedRevisions(server)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      applicationConfig(server, first, "1", ""),
+				ExpectError: regexp.MustCompile(`helm install failed`),
+			},
+		},
+	})
+}
@@ -0,0 +1,127 @@
+package resource_application
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+	"terraform-provider-otc-marketplace/internal/util"
+	"time"
+)
+
+// applicationSchema forces a replacement when the deployment target changes. The marketplace has no endpoint to
+// update an application, so every other change is applied by upgradeApplication.
+func applicationSchema(ctx context.Context) (schema.Schema, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	s := ApplicationResourceSchema(ctx)
+
+	for name, modifiers := range map[string][]planmodifier.String{
+		"project_id": {stringplanmodifier.RequiresReplace()},
+		"cluster_id": {stringplanmodifier.RequiresReplace()},
+		"namespace":  {stringplanmodifier.RequiresReplace()},
+		// Generated by the marketplace if not set, an upgrade keeps the release
+		"release_name": {stringplanmodifier.UseStateForUnknown(), stringplanmodifier.RequiresReplace()},
+		// Computed as well, so they'd be unknown and upgrade the application on every change if they aren't configured
+		"description":  {stringplanmodifier.UseStateForUnknown()},
+		"byol_license": {stringplanmodifier.UseStateForUnknown()},
+	} {
+		attribute, ok := s.Attributes[name].(schema.StringAttribute)
+		if !ok {
+			diags.AddError("Unexpected application schema", fmt.Sprintf("%s is not a string attribute", name))
+			continue
+		}
+		attribute.PlanModifiers = append(attribute.PlanModifiers, modifiers...)
+		s.Attributes[name] = attribute
+	}
+
+	configuration, ok := s.Attributes["configuration"].(schema.ListNestedAttribute)
+	if !ok {
+		diags.AddError("Unexpected application schema", "configuration is not a list nested attribute")
+	} else {
+		configuration.PlanModifiers = append(configuration.PlanModifiers, listplanmodifier.UseStateForUnknown())
+		s.Attributes["configuration"] = configuration
+	}
+	s.Attributes["confidential_configuration"] = confidentialConfigurationAttribute
+
+	return s, diags
+}
+
+// createApplication deploys the planned application and returns the marketplace's response
+func (r *applicationResource) createApplication(ctx context.Context, data applicationResourceModel, diags *diag.Diagnostics) *sellerapi.Application {
+	input, err := applicationResourceModMapper(ctx, data.ApplicationModel)
+	if err != nil {
+		diags.AddError(
+			"Couldn't map plan data into an application", fmt.Sprintf("err: %v", err))
+		return nil
+	}
+	confidential, confidentialDiags := data.confidentialConfiguration(ctx)
+	diags.Append(confidentialDiags...)
+	if diags.HasError() {
+		return nil
+	}
+	input.Configuration = append(input.Configuration, confidential...)
+
+	application, err := r.client.CreateApplication(ctx, *input)
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Couldn't deploy product revision %s", input.ProductRevisionId),
+			fmt.Sprintf("error: %v", err),
+		)
+		return nil
+	}
+	return application
+}
+
+// needsUpgrade reports whether anything that's sent to the marketplace changed, rather than only the timeouts. Planned
+// values are only unknown on apply if they aren't configured, which doesn't change them.
+func needsUpgrade(prior applicationResourceModel, planned applicationResourceModel) bool {
+	changed := func(prior attr.Value, planned attr.Value) bool {
+		return !planned.IsUnknown() && !prior.Equal(planned)
+	}
+	return changed(prior.ProductRevisionId, planned.ProductRevisionId) ||
+		changed(prior.Configuration, planned.Configuration) ||
+		changed(prior.ConfidentialConfiguration, planned.ConfidentialConfiguration) ||
+		changed(prior.Description, planned.Description) ||
+		changed(prior.ByolLicense, planned.ByolLicense)
+}
+
+// upgradeApplication applies a changed revision, configuration, description or license by redeploying the
+// application under the same release: the old application is deleted and, once it's gone, created again from the
+// plan. The marketplace assigns the new deployment a new id.
+func (r *applicationResource) upgradeApplication(ctx context.Context, prior applicationResourceModel, planned applicationResourceModel, timeout time.Duration, diags *diag.Diagnostics) (*sellerapi.Application, error) {
+	deadline := time.Now().Add(timeout)
+	id := prior.Id.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("upgrading application %s (release %s) to product revision %s", id, prior.ReleaseName.ValueString(), planned.ProductRevisionId.ValueString()))
+
+	err := r.client.DeleteApplication(ctx, util.SanitizeString(id))
+	if err != nil && !util.IsNotFound(err) {
+		diags.AddError(
+			fmt.Sprintf("Couldn't delete application %s", id),
+			fmt.Sprintf("error: %v\nthe application has to be removed before it can be deployed with the new revision or configuration", err),
+		)
+		return nil, nil
+	}
+
+	// The release can only be installed again once helm removed it
+	if err := r.waitForApplicationDeleted(ctx, id, time.Until(deadline)); err != nil {
+		diags.AddError("Old application deployment wasn't removed", err.Error())
+		return nil, nil
+	}
+
+	application := r.createApplication(ctx, planned, diags)
+	if application == nil {
+		return nil, nil
+	}
+
+	ready, err := r.waitForApplicationReady(ctx, application.Id, time.Until(deadline))
+	if ready != nil {
+		application = ready
+	}
+	return application, err
+}
@@ -0,0 +1,96 @@
+package resource_application
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+	"terraform-provider-otc-marketplace/internal/util"
+	"time"
+)
+
+const (
+	defaultApplicationCreateTimeout = 30 * time.Minute
+	defaultApplicationUpdateTimeout = 30 * time.Minute
+	defaultApplicationDeleteTimeout = 30 * time.Minute
+	applicationPollInterval         = 10 * time.Second
+)
+
+// applicationResourceModel adds the `timeouts` block and the confidential configuration to the generated model
+type applicationResourceModel struct {
+	ApplicationModel
+	ConfidentialConfiguration types.Map      `tfsdk:"confidential_configuration"`
+	Timeouts                  timeouts.Value `tfsdk:"timeouts"`
+}
+
+// waitForApplicationReady polls the application until its deployment has finished. The last response is returned
+// together with the error if the deployment failed, so the state can still be saved.
+func (r *applicationResource) waitForApplicationReady(ctx context.Context, id string, timeout time.Duration) (*sellerapi.Application, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(applicationPollInterval)
+	defer ticker.Stop()
+
+	var latest *sellerapi.Application
+	for {
+		application, err := r.client.GetApplication(ctx, util.SanitizeString(id))
+		if err != nil {
+			// A request cut off by the timeout is reported as a timeout below
+			if ctx.Err() == nil {
+				return latest, err
+			}
+		} else {
+			latest = application
+			switch application.State {
+			case sellerapi.ApplicationStateReady:
+				return application, nil
+			case sellerapi.ApplicationStateError:
+				message := application.Error
+				if message == "" {
+					message = "the marketplace didn't report a reason"
+				}
+				return application, fmt.Errorf("deployment of application %s failed: %s", id, message)
+			}
+			tflog.Debug(ctx, fmt.Sprintf("application %s is %s, waiting for it to become %s", id, application.State, sellerapi.ApplicationStateReady))
+		}
+
+		select {
+		case <-ctx.Done():
+			lastState := "unknown"
+			if latest != nil {
+				lastState = string(latest.State)
+			}
+			return latest, fmt.Errorf("timed out after %s waiting for application %s to become %s, last state: %s", timeout, id, sellerapi.ApplicationStateReady, lastState)
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForApplicationDeleted polls the application until the marketplace no longer knows it
+func (r *applicationResource) waitForApplicationDeleted(ctx context.Context, id string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(applicationPollInterval)
+	defer ticker.Stop()
+
+	for {
+		_, err := r.client.GetApplication(ctx, util.SanitizeString(id))
+		if util.IsNotFound(err) {
+			return nil
+		}
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+		tflog.Debug(ctx, fmt.Sprintf("application %s is still being removed", id))
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out after %s waiting for application %s to be removed", timeout, id)
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,28 +1,27 @@
 package resource_product
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"net/http"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
 	"terraform-provider-otc-marketplace/internal/util"
 )
 
 var _ resource.Resource = (*productResource)(nil)
-
-const productResourcePath = "/products"
+var _ resource.ResourceWithImportState = (*productResource)(nil)
 
 func NewProductResource() resource.Resource {
 	return &productResource{}
 }
 
 type productResource struct {
-	client *util.MarketplaceAPIClient
+	client *sellerapi.Client
 }
 
 func (r *productResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -40,7 +39,7 @@ func (r *productResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	clientPTR, ok := req.ProviderData.(*util.MarketplaceAPIClient)
+	clientPTR, ok := req.ProviderData.(*sellerapi.Client)
 	if !ok || clientPTR == nil {
 		resp.Diagnostics.AddError(
 			"Provider Configuration Error",
@@ -51,6 +50,11 @@ func (r *productResource) Configure(ctx context.Context, req resource.ConfigureR
 	r.client = clientPTR
 }
 
+// ImportState imports an existing product by its NanoID, e.g. one created in the seller dashboard
+func (r *productResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	util.ImportStateByNanoID(ctx, req, resp)
+}
+
 func (r *productResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ProductModel
 
@@ -82,32 +86,22 @@ func (r *productResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	// TODO - send the whole Product? - Potential inconsistent state issues with stuff like time
-	type CreateJSONRequest struct {
-		LicenseType string `json:"license_type"`
-		Name        string `json:"name"`
-		Type        string `json:"type"`
-		Weight      int64  `json:"weight"`
-	}
-
-	body, err := json.Marshal(CreateJSONRequest{
-		LicenseType: util.SanitizeString(data.LicenseType.String()),
+	product := sellerapi.ProductInput{
+		LicenseType: sellerapi.LicenseType(util.SanitizeString(data.LicenseType.String())),
 		Name:        util.SanitizeString(data.Name.String()),
-		Type:        util.SanitizeString(data.Type.String()),
+		Type:        sellerapi.ProductType(util.SanitizeString(data.Type.String())),
 		Weight:      data.Weight.ValueInt64(),
-	})
-	if err != nil {
-		resp.Diagnostics.AddError("Couldn't marshall product into json", fmt.Sprintf("error: %v", err))
-		return
 	}
 
-	newProductPTR, err := util.MakeMarketplaceRequest[util.ProductDataSourceNativeModel](ctx, http.MethodPost, productResourcePath, bytes.NewReader(body), r.client)
+	newProductPTR, err := r.client.CreateProduct(ctx, product)
 	if err != nil {
 		var potentialReusedName string
-		if err.Error() == "unexpected status code: 500" { // TODO - fragile
+		// The backend answers a reused name with a 500, so keep the hint for that as well until it returns a 409
+		if util.IsConflict(err) || util.StatusCode(err) == http.StatusInternalServerError {
 			potentialReusedName = "This might mean you're trying to create a product with a previously used name. \nProduct names on the OTC must be new and unique."
 		}
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %s", http.MethodPost, productResourcePath, body),
+			fmt.Sprintf("Couldn't create product %s", product.Name),
 			fmt.Sprintf("%s %+v", potentialReusedName, err),
 		)
 		return
@@ -139,11 +133,15 @@ func (r *productResource) Read(ctx context.Context, req resource.ReadRequest, re
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
-	url := fmt.Sprintf("%s/%s", productResourcePath, util.SanitizeString(data.Id.ValueString()))
-	newProductPTR, err := util.MakeMarketplaceRequest[util.ProductDataSourceNativeModel](ctx, http.MethodGet, url, nil, r.client)
+	newProductPTR, err := r.client.GetProduct(ctx, util.SanitizeString(data.Id.ValueString()))
+	if util.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("product %s no longer exists, removing it from the state", data.Id.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %v", http.MethodGet, url, nil),
+			fmt.Sprintf("Couldn't read product %s", data.Id.ValueString()),
 			fmt.Sprintf("error: %v", err),
 		)
 		return
@@ -209,31 +207,22 @@ func (r *productResource) Update(ctx context.Context, req resource.UpdateRequest
 	data.Name = util.SanitizeStringValue(data.Name)
 	data.Type = util.SanitizeStringValue(data.Type)
 
-	type UpdateJSONRequest struct {
-		EOL         bool   `json:"eol"`
-		LicenseType string `json:"license_type"`
-		Name        string `json:"name"`
-		Type        string `json:"type"`
-		Weight      int64  `json:"weight"`
+	// eol is computed, so it's unknown in the plan if it isn't configured
+	if data.Eol.IsUnknown() {
+		data.Eol = priorState.Eol
 	}
 
-	body, err := json.Marshal(UpdateJSONRequest{
-		EOL:         data.Eol.ValueBool(),
-		LicenseType: util.SanitizeString(data.LicenseType.String()),
+	eol := data.Eol.ValueBool()
+	newProductPTR, err := r.client.EditProduct(ctx, util.SanitizeString(data.Id.ValueString()), sellerapi.ProductInput{
+		Eol:         &eol,
+		LicenseType: sellerapi.LicenseType(util.SanitizeString(data.LicenseType.String())),
 		Name:        util.SanitizeString(data.Name.String()),
-		Type:        util.SanitizeString(data.Type.String()),
+		Type:        sellerapi.ProductType(util.SanitizeString(data.Type.String())),
 		Weight:      data.Weight.ValueInt64(),
 	})
-	if err != nil {
-		resp.Diagnostics.AddError("Couldn't marshall product into json", fmt.Sprintf("error: %v", err))
-		return
-	}
-
-	url := fmt.Sprintf("%s/%s", productResourcePath, util.SanitizeString(data.Id.ValueString()))
-	newProductPTR, err := util.MakeMarketplaceRequest[util.ProductDataSourceNativeModel](ctx, http.MethodPatch, url, bytes.NewReader(body), r.client)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %s", http.MethodPatch, url, body),
+			fmt.Sprintf("Couldn't update product %s", data.Id.ValueString()),
 			fmt.Sprintf("error: %v", err),
 		)
 		return
@@ -257,12 +246,12 @@ func (r *productResource) Update(ctx context.Context, req resource.UpdateRequest
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func ProductResourceMapper(ctx context.Context, newProductPTR *util.ProductDataSourceNativeModel) (*ProductModel, error) {
+func ProductResourceMapper(ctx context.Context, newProductPTR *sellerapi.Product) (*ProductModel, error) {
 	sellerObj, diags := NewSellerValue(SellerValue{}.AttributeTypes(ctx), map[string]attr.Value{
 		"description":   util.StringSetOrNull(newProductPTR.Seller.Description),
 		"id":            util.StringSetOrNull(newProductPTR.Seller.Id),
 		"name":          util.StringSetOrNull(newProductPTR.Seller.Name),
-		"state":         util.StringSetOrNull(newProductPTR.Seller.State),
+		"state":         util.StringSetOrNull(string(newProductPTR.Seller.State)),
 		"support_email": util.StringSetOrNull(newProductPTR.Seller.SupportEmail),
 		"support_url":   util.StringSetOrNull(newProductPTR.Seller.SupportUrl),
 	})
@@ -272,14 +261,14 @@ func ProductResourceMapper(ctx context.Context, newProductPTR *util.ProductDataS
 	data := ProductModel{
 		ActiveRevisionId: util.StringSetOrNull(newProductPTR.ActiveRevisionId),
 		CreatedAt:        util.StringSetOrNull(newProductPTR.CreatedAt),
-		Eol:              types.BoolValue(newProductPTR.EOL),
-		EolDate:          util.StringSetOrNull(newProductPTR.EOLDate),
+		Eol:              types.BoolValue(newProductPTR.Eol),
+		EolDate:          util.StringSetOrNull(newProductPTR.EolDate),
 		Id:               util.StringSetOrNull(newProductPTR.Id),
-		LicenseType:      util.StringSetOrNull(newProductPTR.LicenseType),
+		LicenseType:      util.StringSetOrNull(string(newProductPTR.LicenseType)),
 		Name:             util.StringSetOrNull(newProductPTR.Name),
 		Seller:           sellerObj,
-		State:            util.StringSetOrNull(newProductPTR.State),
-		Type:             util.StringSetOrNull(newProductPTR.Type),
+		State:            util.StringSetOrNull(string(newProductPTR.State)),
+		Type:             util.StringSetOrNull(string(newProductPTR.Type)),
 		Weight:           types.Int64Value(newProductPTR.Weight),
 	}
 
@@ -292,11 +281,15 @@ func (r *productResource) Delete(ctx context.Context, req resource.DeleteRequest
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
-	url := fmt.Sprintf("%s/%s", productResourcePath, util.SanitizeString(data.Id.ValueString()))
-	_, err := util.MakeMarketplaceRequest[struct{}](ctx, http.MethodDelete, url, nil, r.client)
+	err := r.client.DeleteProduct(ctx, util.SanitizeString(data.Id.ValueString()))
+	if util.IsNotFound(err) {
+		// Already gone, e.g. deleted in the seller dashboard
+		tflog.Info(ctx, fmt.Sprintf("product %s was already deleted", data.Id.ValueString()))
+		err = nil
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %v", http.MethodDelete, url, nil),
+			fmt.Sprintf("Couldn't delete product %s", data.Id.ValueString()),
 			fmt.Sprintf("error: %v", err),
 		)
 		return
@@ -0,0 +1,128 @@
+package resource_product_test
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"regexp"
+	"terraform-provider-otc-marketplace/internal/acctest"
+	"terraform-provider-otc-marketplace/internal/testserver"
+	"testing"
+)
+
+func productConfig(server *testserver.Server, name string) string {
+	return acctest.ProviderConfig(server) + fmt.Sprintf(`
+resource "otc-marketplace_product" "test" {
+  name         = %q
+  type         = "k8s"
+  license_type = "free"
+  weight       = 0
+
+  # As in the README, the seller is set by the marketplace and shows up as a change otherwise
+  lifecycle {
+    ignore_changes = [seller]
+  }
+}
+`, name)
+}
+
+func TestProductResource(t *testing.T) {
+	server := acctest.NewServer(t, testserver.Options{})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if products := server.List(testserver.Products); len(products) > 0 {
+				return fmt.Errorf("expected the product to be deleted, got %v", products)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: productConfig(server, "Prometheus exporter"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("otc-marketplace_product.test", "id", regexp.MustCompile(`^[\w-]{21}$`)),
+					resource.TestCheckResourceAttr("otc-marketplace_product.test", "name", "Prometheus exporter"),
+					resource.TestCheckResourceAttr("otc-marketplace_product.test", "state", "de-published"),
+					resource.TestCheckResourceAttr("otc-marketplace_product.test", "eol", "false"),
+					resource.TestCheckResourceAttr("otc-marketplace_product.test", "seller.name", acctest.Username),
+				),
+			},
+			{
+				Config: productConfig(server, "Prometheus exporter for OTC"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("otc-marketplace_product.test", "name", "Prometheus exporter for OTC"),
+					func(state *terraform.State) error {
+						id := state.RootModule().Resources["otc-marketplace_product.test"].Primary.ID
+						product, ok := server.Get(testserver.Products, id)
+						if !ok || product["name"] != "Prometheus exporter for OTC" {
+							return fmt.Errorf("expected the product to be renamed in place, got %v", product)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "otc-marketplace_product.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestProductResourceReusedName(t *testing.T) {
+	server := acctest.NewServer(t, testserver.Options{})
+	server.Seed(testserver.Products, testserver.Object{"name": "Prometheus exporter", "type": "k8s", "license_type": "free"})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      productConfig(server, "Prometheus exporter"),
+				ExpectError: regexp.MustCompile(`previously\s+used\s+name`),
+			},
+		},
+	})
+}
+
+func TestProductResourceDeletedOutsideTerraform(t *testing.T) {
+	server := acctest.NewServer(t, testserver.Options{})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: productConfig(server, "Prometheus exporter"),
+			},
+			{
+				// Deleted in the seller dashboard, so the next plan creates it again
+				PreConfig: func() {
+					for _, product := range server.List(testserver.Products) {
+						server.InjectFault(testserver.Fault{Method: "GET", Path: fmt.Sprintf("/products/%s", product["id"]), StatusCode: 404})
+					}
+				},
+				Config:             productConfig(server, "Prometheus exporter"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestProductResourceImportInvalidId(t *testing.T) {
+	server := acctest.NewServer(t, testserver.Options{})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        productConfig(server, "Prometheus exporter"),
+				ResourceName:  "otc-marketplace_product.test",
+				ImportState:   true,
+				ImportStateId: "not-a-nanoid",
+				ExpectError:   regexp.MustCompile(`NanoID`),
+			},
+		},
+	})
+}
@@ -0,0 +1,187 @@
+package resource_product_revision
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+	"terraform-provider-otc-marketplace/internal/util"
+)
+
+var _ resource.ResourceWithModifyPlan = (*productRevisionResource)(nil)
+
+// productRevisionResourceModel adds the attributes that load the icon and contractual documents from local files to
+// the generated model. Only the hashes of the files are stored, so the plan doesn't show their content.
+type productRevisionResourceModel struct {
+	ProductRevisionModel
+	IconFile                 types.String `tfsdk:"icon_file"`
+	IconSha256               types.String `tfsdk:"icon_sha256"`
+	ContractualDocumentFiles types.List   `tfsdk:"contractual_document_files"`
+}
+
+type contractualDocumentFileModel struct {
+	SourcePath types.String `tfsdk:"source_path"`
+	FileName   types.String `tfsdk:"file_name"`
+	Sha256     types.String `tfsdk:"sha256"`
+}
+
+var contractualDocumentFileType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"source_path": types.StringType,
+	"file_name":   types.StringType,
+	"sha256":      types.StringType,
+}}
+
+// addFileAttributes adds icon_file and contractual_document_files to the schema
+func addFileAttributes(s *schema.Schema) {
+	s.Attributes["icon_file"] = schema.StringAttribute{
+		Optional: true,
+		Description: "Path to a PNG, JPEG or GIF icon in 16:9 format, sent as `icon`. Changes are tracked by `icon_sha256`, " +
+			"the icon itself isn't stored in the state.",
+		Validators: []validator.String{stringvalidator.ConflictsWith(path.MatchRoot("icon"))},
+	}
+	s.Attributes["icon_sha256"] = schema.StringAttribute{
+		Computed:    true,
+		Description: "SHA-256 of the content of `icon_file`",
+	}
+	s.Attributes["contractual_document_files"] = schema.ListNestedAttribute{
+		Optional: true,
+		Description: "Legal documents to upload from local files, sent together with `contractual_documents`. Changes " +
+			"are tracked by their `sha256`, the documents themselves aren't stored in the state.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"source_path": schema.StringAttribute{
+					Required:    true,
+					Description: "Path to the document",
+				},
+				"file_name": schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "Name of the file shown to customers, defaults to the name of the source file",
+				},
+				"sha256": schema.StringAttribute{
+					Computed:    true,
+					Description: "SHA-256 of the content of the document",
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan reads the files, so invalid ones fail the plan and changed ones show up as a changed hash
+func (r *productRevisionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to read when destroying
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planned productRevisionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planned)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	iconSha256 := types.StringNull()
+	switch {
+	case planned.IconFile.IsUnknown():
+		iconSha256 = types.StringUnknown()
+	case !planned.IconFile.IsNull():
+		icon, err := util.LoadIcon(planned.IconFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("icon_file"), "Invalid icon", err.Error())
+			return
+		}
+		iconSha256 = types.StringValue(icon.SHA256())
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("icon_sha256"), iconSha256)...)
+
+	if planned.ContractualDocumentFiles.IsNull() || planned.ContractualDocumentFiles.IsUnknown() {
+		return
+	}
+	var documents []contractualDocumentFileModel
+	resp.Diagnostics.Append(planned.ContractualDocumentFiles.ElementsAs(ctx, &documents, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, document := range documents {
+		if document.SourcePath.IsUnknown() {
+			documents[i].Sha256 = types.StringUnknown()
+			continue
+		}
+		file, err := util.LoadDocument(document.SourcePath.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("contractual_document_files").AtListIndex(i).AtName("source_path"), "Invalid contractual document", err.Error())
+			continue
+		}
+		if document.FileName.IsNull() || document.FileName.IsUnknown() {
+			documents[i].FileName = types.StringValue(file.Name)
+		}
+		documents[i].Sha256 = types.StringValue(file.SHA256())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	documentsList, diags := types.ListValueFrom(ctx, contractualDocumentFileType, documents)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("contractual_document_files"), documentsList)...)
+}
+
+// addFiles sends the icon and contractual documents loaded from files with the revision
+func (m productRevisionResourceModel) addFiles(ctx context.Context, revision *sellerapi.ProductRevision) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !m.IconFile.IsNull() {
+		icon, err := util.LoadIcon(m.IconFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("icon_file"), "Invalid icon", err.Error())
+			return diags
+		}
+		revision.Icon = icon.DataURI()
+	}
+
+	if m.ContractualDocumentFiles.IsNull() {
+		return diags
+	}
+	var documents []contractualDocumentFileModel
+	diags.Append(m.ContractualDocumentFiles.ElementsAs(ctx, &documents, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	for i, document := range documents {
+		file, err := util.LoadDocument(document.SourcePath.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("contractual_document_files").AtListIndex(i).AtName("source_path"), "Invalid contractual document", err.Error())
+			continue
+		}
+		revision.ContractualDocuments = append(revision.ContractualDocuments, sellerapi.ContractualDocument{
+			FileName: document.FileName.ValueString(),
+			Content:  file.DataURI(),
+		})
+	}
+	return diags
+}
+
+// withFiles returns the revision read from the marketplace with the file attributes of m. The marketplace only knows
+// the icon's content, so an icon set by icon_file isn't compared.
+func (m productRevisionResourceModel) withFiles(actual *ProductRevisionModel) *productRevisionResourceModel {
+	if !m.IconFile.IsNull() {
+		actual.Icon = types.StringNull()
+	}
+	return &productRevisionResourceModel{
+		ProductRevisionModel:     *actual,
+		IconFile:                 m.IconFile,
+		IconSha256:               m.IconSha256,
+		ContractualDocumentFiles: m.ContractualDocumentFiles,
+	}
+}
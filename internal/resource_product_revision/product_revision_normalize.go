@@ -0,0 +1,231 @@
+package resource_product_revision
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"sort"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+	"time"
+)
+
+// productRevisionSchema marks the attributes only the marketplace sets as Computed, so they don't show up as drift
+// after every apply. The generated schema treats them as Optional, as the API uses the same object for requests and
+// responses.
+func productRevisionSchema(ctx context.Context) (schema.Schema, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	s := ProductRevisionResourceSchema(ctx)
+
+	// Stable once assigned
+	for name, modifiers := range map[string][]planmodifier.String{
+		"id":                           {stringplanmodifier.UseStateForUnknown()},
+		"scheduled_release_date":       {stringplanmodifier.UseStateForUnknown()},
+		"scheduled_release_until_date": {stringplanmodifier.UseStateForUnknown()},
+		// Changed by the review, which may be triggered by any update
+		"state":            nil,
+		"admin_suggestion": nil,
+	} {
+		attribute, ok := s.Attributes[name].(schema.StringAttribute)
+		if !ok {
+			diags.AddError("Unexpected product_revision schema", fmt.Sprintf("%s is not a string attribute", name))
+			continue
+		}
+		attribute.Required = false
+		attribute.Optional = false
+		attribute.Computed = true
+		attribute.PlanModifiers = append(attribute.PlanModifiers, modifiers...)
+		s.Attributes[name] = attribute
+	}
+
+	number, ok := s.Attributes["number"].(schema.Int64Attribute)
+	if !ok {
+		diags.AddError("Unexpected product_revision schema", "number is not an int64 attribute")
+	} else {
+		number.Required = false
+		number.Optional = false
+		number.Computed = true
+		number.PlanModifiers = append(number.PlanModifiers, int64planmodifier.UseStateForUnknown())
+		s.Attributes["number"] = number
+	}
+
+	docsInfo, ok := s.Attributes["contractual_documents_info"].(schema.ListNestedAttribute)
+	if !ok {
+		diags.AddError("Unexpected product_revision schema", "contractual_documents_info is not a list nested attribute")
+	} else {
+		docsInfo.Required = false
+		docsInfo.Optional = false
+		docsInfo.Computed = true
+		docsInfo.PlanModifiers = append(docsInfo.PlanModifiers,
+			useStateUnlessChanged(path.Root("contractual_documents"), path.Root("contractual_document_files")))
+		s.Attributes["contractual_documents_info"] = docsInfo
+	}
+
+	addFileAttributes(&s)
+
+	return s, diags
+}
+
+// useStateUnlessChanged keeps the prior state of a computed list, unless one of the attributes at triggers is changed
+// by the plan (e.g. the uploaded documents that contractual_documents_info describes).
+func useStateUnlessChanged(triggers ...path.Path) planmodifier.List {
+	return useStateUnlessChangedModifier{triggers: triggers}
+}
+
+type useStateUnlessChangedModifier struct {
+	triggers []path.Path
+}
+
+func (m useStateUnlessChangedModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("Keeps the prior state unless %s changes.", path.Paths(m.triggers))
+}
+
+func (m useStateUnlessChangedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateUnlessChangedModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	// Nothing to keep on create, and nothing to change if the config sets the value
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, trigger := range m.triggers {
+		var planned, prior types.List
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, trigger, &planned)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, trigger, &prior)...)
+		if resp.Diagnostics.HasError() || planned.IsUnknown() || !planned.Equal(prior) {
+			return
+		}
+	}
+
+	resp.PlanValue = req.StateValue
+}
+
+// normalizeProductRevision keeps the configured value of every attribute the marketplace returned in an equivalent
+// but differently formatted way, e.g. a reformatted date or an empty list instead of null. Values that really differ
+// are taken from actual, so changes made outside of Terraform are still detected.
+func normalizeProductRevision(ctx context.Context, configured ProductRevisionModel, actual *ProductRevisionModel) {
+	for _, pair := range []struct {
+		configured types.String
+		actual     *types.String
+	}{
+		{configured.Description, &actual.Description},
+		{configured.DescriptionShort, &actual.DescriptionShort},
+		{configured.Eula, &actual.Eula},
+		{configured.Guidance, &actual.Guidance},
+		{configured.HelmExternal, &actual.HelmExternal},
+		{configured.Icon, &actual.Icon},
+		{configured.LicenseFee, &actual.LicenseFee},
+		{configured.LicenseInfo, &actual.LicenseInfo},
+		{configured.PostDeploymentInfo, &actual.PostDeploymentInfo},
+		{configured.PreDeploymentInfo, &actual.PreDeploymentInfo},
+		{configured.PricingInfo, &actual.PricingInfo},
+		{configured.ProductId, &actual.ProductId},
+		{configured.Version, &actual.Version},
+	} {
+		// The marketplace drops empty strings
+		if !pair.configured.IsUnknown() && pair.configured.ValueString() == pair.actual.ValueString() {
+			*pair.actual = pair.configured
+		}
+	}
+
+	if datesEqual(configured.ProposedReleaseDate, actual.ProposedReleaseDate) {
+		actual.ProposedReleaseDate = configured.ProposedReleaseDate
+	}
+
+	if byolEquivalent(configured.Byol, actual.Byol) {
+		actual.Byol = configured.Byol
+	}
+
+	if categoriesEquivalent(ctx, configured.Categories, actual.Categories) {
+		actual.Categories = configured.Categories
+	}
+	if listsEquivalent[sellerapi.ConfigurationTemplate](ctx, configured.ProductRevisionApplicationConfiguration, actual.ProductRevisionApplicationConfiguration) {
+		actual.ProductRevisionApplicationConfiguration = configured.ProductRevisionApplicationConfiguration
+	}
+	if listsEquivalent[sellerapi.UsedSoftware](ctx, configured.UsedSoftware, actual.UsedSoftware) {
+		actual.UsedSoftware = configured.UsedSoftware
+	}
+
+	// Only sent on create and update, the marketplace returns the uploaded files as contractual_documents_info
+	if !configured.ContractualDocuments.IsUnknown() {
+		actual.ContractualDocuments = configured.ContractualDocuments
+	}
+}
+
+// byolEquivalent treats a null byol and one without any values as the same
+func byolEquivalent(configured ByolValue, actual ByolValue) bool {
+	return !configured.IsUnknown() &&
+		configured.ActivationUrl.ValueString() == actual.ActivationUrl.ValueString() &&
+		configured.FileNameInSecret.ValueString() == actual.FileNameInSecret.ValueString() &&
+		configured.SecretName.ValueString() == actual.SecretName.ValueString() &&
+		configured.WebshopUrl.ValueString() == actual.WebshopUrl.ValueString()
+}
+
+// datesEqual compares two RFC 3339 timestamps, the marketplace may return them with a different precision or zone
+func datesEqual(configured types.String, actual types.String) bool {
+	if configured.IsUnknown() {
+		return false
+	}
+	if configured.ValueString() == actual.ValueString() {
+		return true
+	}
+
+	configuredTime, err := time.Parse(time.RFC3339, configured.ValueString())
+	if err != nil {
+		return false
+	}
+	actualTime, err := time.Parse(time.RFC3339, actual.ValueString())
+	if err != nil {
+		return false
+	}
+	return configuredTime.Equal(actualTime)
+}
+
+// categoriesEquivalent ignores the order, the marketplace doesn't keep it
+func categoriesEquivalent(ctx context.Context, configured types.List, actual types.List) bool {
+	var configuredIds, actualIds []string
+	if !toNative(ctx, configured, &configuredIds) || !toNative(ctx, actual, &actualIds) {
+		return false
+	}
+	sort.Strings(configuredIds)
+	sort.Strings(actualIds)
+	return jsonEqual(configuredIds, actualIds)
+}
+
+// listsEquivalent compares two lists by the JSON the marketplace would receive for them, which ignores the difference
+// between null, empty and unset values
+func listsEquivalent[N any](ctx context.Context, configured types.List, actual types.List) bool {
+	var configuredElems, actualElems []N
+	if !toNative(ctx, configured, &configuredElems) || !toNative(ctx, actual, &actualElems) {
+		return false
+	}
+	return jsonEqual(configuredElems, actualElems)
+}
+
+func toNative[N any](ctx context.Context, l types.List, target *[]N) bool {
+	if l.IsUnknown() {
+		return false
+	}
+	if l.IsNull() {
+		return true
+	}
+	return !l.ElementsAs(ctx, target, false).HasError()
+}
+
+func jsonEqual[N any](a []N, b []N) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(aJSON, bJSON)
+}
@@ -1,15 +1,13 @@
 package resource_product_revision
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"net/http"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
 	"terraform-provider-otc-marketplace/internal/util"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -17,135 +15,24 @@ import (
 )
 
 var _ resource.Resource = (*productRevisionResource)(nil)
-
-const productRevisionResourcePath = "/product-revisions"
+var _ resource.ResourceWithImportState = (*productRevisionResource)(nil)
 
 func NewProductRevisionResource() resource.Resource {
 	return &productRevisionResource{}
 }
 
 type productRevisionResource struct {
-	client *util.MarketplaceAPIClient
-}
-
-type ProductRevisionResourceNativeModel struct {
-	AdminSuggestion           string                                           `json:"admin_suggestion,omitempty" tfsdk:"admin_suggestion"`
-	Eula                      string                                           `json:"eula,omitempty" tfsdk:"eula"`
-	Configuration             []ProductRevisionResourceConfigNativeModel       `json:"configuration,omitempty" tfsdk:"product_revision_application_configuration"`
-	ContractualDocumentsInfo  []contractualDocsInfoNativeModel                 `json:"contractual_documents_info,omitempty" tfsdk:"contractual_documents_info"`
-	Categories                []string                                         `json:"categories,omitempty" tfsdk:"categories"`
-	Description               string                                           `json:"description,omitempty" tfsdk:"description"`
-	DescriptionShort          string                                           `json:"description_short,omitempty" tfsdk:"description_short"`
-	Guidance                  string                                           `json:"guidance,omitempty" tfsdk:"guidance"`
-	HelmExternal              string                                           `json:"helm_external,omitempty" tfsdk:"helm_external"`
-	Icon                      string                                           `json:"icon,omitempty" tfsdk:"icon"`
-	Id                        string                                           `json:"id,omitempty" tfsdk:"id"`
-	LicenseFee                string                                           `json:"license_fee,omitempty" tfsdk:"license_fee"`
-	LicenseInfo               string                                           `json:"license_info,omitempty" tfsdk:"license_info"`
-	PostDeploymentInfo        string                                           `json:"post_deployment_info,omitempty" tfsdk:"post_deployment_info"`
-	PreDeploymentInfo         string                                           `json:"pre_deployment_info,omitempty" tfsdk:"pre_deployment_info"`
-	PricingInfo               string                                           `json:"pricing_info,omitempty" tfsdk:"pricing_info"`
-	ProductId                 string                                           `json:"product_id,omitempty" tfsdk:"product_id"`
-	ProposedReleaseDate       string                                           `json:"proposed_release_date,omitempty" tfsdk:"proposed_release_date"`
-	ScheduledReleaseDate      string                                           `json:"scheduled_release_date,omitempty" tfsdk:"scheduled_release_date"`
-	ScheduledReleaseUntilDate string                                           `json:"scheduled_release_until_date,omitempty" tfsdk:"scheduled_release_until_date"`
-	State                     string                                           `json:"state,omitempty" tfsdk:"state"`
-	Number                    int64                                            `json:"number,omitempty" tfsdk:"number"`
-	UsedSoftware              []productRevisionResourceUsedSoftwareNativeModel `json:"used_software,omitempty" tfsdk:"used_software"`
-	Version                   string                                           `json:"version,omitempty" tfsdk:"version"`
-	Byol                      productRevisionByolNativeModel                   `json:"byol,omitempty" tfsdk:"byol"`
-}
-
-type productRevisionByolNativeModel struct {
-	ActivationUrl    string `json:"activation_url,omitempty" tfsdk:"activation_url"`
-	FileNameInSecret string `json:"file_name_in_secret,omitempty" tfsdk:"file_name_in_secret"`
-	SecretName       string `json:"secret_name,omitempty" tfsdk:"secret_name"`
-	WebshopUrl       string `json:"webshop_url,omitempty" tfsdk:"webshop_url"`
-}
-type contractualDocsInfoNativeModel struct {
-	Filename string `json:"file_name,omitempty" tfsdk:"file_name"`
-	Url      string `json:"url,omitempty" tfsdk:"url"`
-}
-
-type ContractualDocsNativeModel struct {
-	Filename  string `json:"file_name,omitempty" tfsdk:"file_name"`
-	Content   string `json:"content,omitempty" tfsdk:"content"`
-	IsDeleted bool   `json:"is_deleted,omitempty" tfsdk:"is_deleted"`
-}
-
-type ProductRevisionResourceConfigNativeModel struct {
-	DefaultValue string                                               `json:"default_value,omitempty" tfsdk:"default_value"`
-	Confidential bool                                                 `json:"confidential,omitempty" tfsdk:"confidential"`
-	Hidden       bool                                                 `json:"hidden,omitempty" tfsdk:"hidden"`
-	Hint         string                                               `json:"hint,omitempty" tfsdk:"hint"`
-	InputType    string                                               `json:"input_type,omitempty" tfsdk:"input_type"`
-	Key          string                                               `json:"key,omitempty" tfsdk:"key"`
-	Label        string                                               `json:"label,omitempty" tfsdk:"label"`
-	Multiple     bool                                                 `json:"multiple,omitempty" tfsdk:"multiple"`
-	Required     bool                                                 `json:"required,omitempty" tfsdk:"required"`
-	Tooltip      string                                               `json:"tooltip,omitempty" tfsdk:"tooltip"`
-	Validation   []productRevisionResourceConfigValidationNativeModel `json:"validation,omitempty" tfsdk:"validation"`
-	Values       []productRevisionResourceConfigValueNativeModel      `json:"values,omitempty" tfsdk:"values"`
-}
-
-type ProductRevisionResourceConfigSwitchNativeModel struct {
-	DefaultValue bool                                                 `json:"default_value,omitempty" tfsdk:"default_value"`
-	Confidential bool                                                 `json:"confidential,omitempty" tfsdk:"confidential"`
-	Hidden       bool                                                 `json:"hidden,omitempty" tfsdk:"hidden"`
-	Hint         string                                               `json:"hint,omitempty" tfsdk:"hint"`
-	InputType    string                                               `json:"input_type,omitempty" tfsdk:"input_type"`
-	Key          string                                               `json:"key,omitempty" tfsdk:"key"`
-	Label        string                                               `json:"label,omitempty" tfsdk:"label"`
-	Multiple     bool                                                 `json:"multiple,omitempty" tfsdk:"multiple"`
-	Required     bool                                                 `json:"required,omitempty" tfsdk:"required"`
-	Tooltip      string                                               `json:"tooltip,omitempty" tfsdk:"tooltip"`
-	Validation   []productRevisionResourceConfigValidationNativeModel `json:"validation,omitempty" tfsdk:"validation"`
-	Values       []productRevisionResourceConfigValueNativeModel      `json:"values,omitempty" tfsdk:"values"`
-}
-
-type productRevisionResourceConfigValidationNativeModel struct {
-	Message string `json:"message,omitempty" tfsdk:"message"`
-	Pattern string `json:"pattern,omitempty" tfsdk:"pattern"`
-}
-
-type productRevisionResourceConfigValueNativeModel struct {
-	Value string `json:"value,omitempty" tfsdk:"value"`
-	Label string `json:"label,omitempty" tfsdk:"label"`
-}
-
-type productRevisionResourceUsedSoftwareNativeModel struct {
-	LicenseName string `json:"license_name,omitempty" tfsdk:"license_name"`
-	LicenseUrl  string `json:"license_url,omitempty" tfsdk:"license_url"`
-	Name        string `json:"name,omitempty" tfsdk:"name"`
+	client *sellerapi.Client
 }
 
 func (r *productRevisionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_product_revision"
 }
 
-type productRevisionResourceNativeModModel struct {
-	Byol                 productRevisionByolNativeModel                   `json:"byol,omitempty" tfsdk:"byol"`
-	Categories           []string                                         `json:"categories,omitempty" tfsdk:"categories"`
-	ProductId            string                                           `json:"product_id,omitempty" tfsdk:"product_id"`
-	Description          string                                           `json:"description,omitempty" tfsdk:"description"`
-	DescriptionShort     string                                           `json:"description_short,omitempty" tfsdk:"description_short"`
-	Icon                 string                                           `json:"icon,omitempty" tfsdk:"icon"`
-	PreDeploymentInfo    string                                           `json:"pre_deployment_info,omitempty" tfsdk:"pre_deployment_info"`
-	PostDeploymentInfo   string                                           `json:"post_deployment_info,omitempty" tfsdk:"post_deployment_info"`
-	ProposedReleaseDate  string                                           `json:"proposed_release_date,omitempty" tfsdk:"proposed_release_date"`
-	LicenseInfo          string                                           `json:"license_info,omitempty" tfsdk:"license_info"`
-	Guidance             string                                           `json:"guidance,omitempty" tfsdk:"guidance"`
-	Version              string                                           `json:"version,omitempty" tfsdk:"version"`
-	HelmExternal         string                                           `json:"helm_external,omitempty" tfsdk:"helm_external"`
-	LicenseFee           string                                           `json:"license_fee,omitempty" tfsdk:"license_fee"`
-	PricingInfo          string                                           `json:"pricing_info,omitempty" tfsdk:"pricing_info"`
-	UsedSoftware         []productRevisionResourceUsedSoftwareNativeModel `json:"used_software,omitempty" tfsdk:"used_software"`
-	Configuration        []ProductRevisionResourceConfigNativeModel       `json:"configuration,omitempty" tfsdk:"product_revision_application_configuration"`
-	ContractualDocuments []ContractualDocsNativeModel                     `json:"contractual_documents,omitempty" tfsdk:"contractual_documents"`
-}
-
 func (r *productRevisionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = ProductRevisionResourceSchema(ctx)
+	s, diags := productRevisionSchema(ctx)
+	resp.Diagnostics.Append(diags...)
+	resp.Schema = s
 }
 
 func (r *productRevisionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -155,7 +42,7 @@ func (r *productRevisionResource) Configure(ctx context.Context, req resource.Co
 		return
 	}
 
-	clientPTR, ok := req.ProviderData.(*util.MarketplaceAPIClient)
+	clientPTR, ok := req.ProviderData.(*sellerapi.Client)
 	if !ok || clientPTR == nil {
 		resp.Diagnostics.AddError(
 			"Provider Configuration Error",
@@ -166,8 +53,13 @@ func (r *productRevisionResource) Configure(ctx context.Context, req resource.Co
 	r.client = clientPTR
 }
 
+// ImportState imports an existing product revision by its NanoID, e.g. one created in the seller dashboard
+func (r *productRevisionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	util.ImportStateByNanoID(ctx, req, resp)
+}
+
 // TODO - cleaner to just return diags and check for errors in whatever calls this?
-func ProductRevisionMapper(ctx context.Context, newDataNativePTR *ProductRevisionResourceNativeModel) (*ProductRevisionModel, error) {
+func ProductRevisionMapper(ctx context.Context, newDataNativePTR *sellerapi.ProductRevision) (*ProductRevisionModel, error) {
 
 	var diags diag.Diagnostics
 
@@ -223,10 +115,10 @@ func ProductRevisionMapper(ctx context.Context, newDataNativePTR *ProductRevisio
 
 		confObj, confDiags := NewProductRevisionApplicationConfigurationValue(ProductRevisionApplicationConfigurationValue{}.AttributeTypes(ctx), map[string]attr.Value{
 			"confidential":  types.BoolValue(conf.Confidential),
-			"default_value": types.StringValue(conf.DefaultValue),
+			"default_value": types.StringValue(string(conf.DefaultValue)),
 			"hidden":        types.BoolValue(conf.Hidden),
 			"hint":          types.StringValue(conf.Hint),
-			"input_type":    types.StringValue(conf.InputType),
+			"input_type":    types.StringValue(string(conf.InputType)),
 			"key":           types.StringValue(conf.Key),
 			"label":         types.StringValue(conf.Label),
 			"multiple":      types.BoolValue(conf.Multiple),
@@ -274,7 +166,7 @@ func ProductRevisionMapper(ctx context.Context, newDataNativePTR *ProductRevisio
 	}
 	for _, docs := range newDataNativePTR.ContractualDocumentsInfo {
 		docsObj, docsDiags := NewContractualDocumentsInfoValue(ContractualDocumentsInfoValue{}.AttributeTypes(ctx), map[string]attr.Value{
-			"file_name": types.StringValue(docs.Filename),
+			"file_name": types.StringValue(docs.FileName),
 			"url":       types.StringValue(docs.Url),
 		})
 		diags.Append(docsDiags...)
@@ -298,50 +190,61 @@ func ProductRevisionMapper(ctx context.Context, newDataNativePTR *ProductRevisio
 		return nil, errors.New(fmt.Sprintf("error: %v", diags.Errors()))
 	}
 
+	// The zero value of ByolValue is null, so it has to be built explicitly to not lose it on Read
+	byolObj := NewByolValueNull()
+	if newDataNativePTR.Byol != nil && *newDataNativePTR.Byol != (sellerapi.Byol{}) {
+		var byolDiags diag.Diagnostics
+		byolObj, byolDiags = NewByolValue(ByolValue{}.AttributeTypes(ctx), map[string]attr.Value{
+			"activation_url":      util.StringSetOrNull(newDataNativePTR.Byol.ActivationUrl),
+			"file_name_in_secret": util.StringSetOrNull(newDataNativePTR.Byol.FileNameInSecret),
+			"secret_name":         util.StringSetOrNull(newDataNativePTR.Byol.SecretName),
+			"webshop_url":         util.StringSetOrNull(newDataNativePTR.Byol.WebshopUrl),
+		})
+		diags.Append(byolDiags...)
+		if diags.HasError() {
+			return nil, errors.New(fmt.Sprintf("error: %v", diags.Errors()))
+		}
+	}
+
 	newData := ProductRevisionModel{
-		AdminSuggestion:                         types.StringValue(newDataNativePTR.AdminSuggestion),
-		Eula:                                    types.StringValue(newDataNativePTR.Eula),
+		AdminSuggestion:                         util.StringSetOrNull(newDataNativePTR.AdminSuggestion),
+		Eula:                                    util.StringSetOrNull(newDataNativePTR.Eula),
 		Categories:                              categoriesAsList,
 		ProductRevisionApplicationConfiguration: configsAsList,
 		ContractualDocuments:                    emptyContractualDocsList,
 		ContractualDocumentsInfo:                docsInfoAsList,
-		Description:                             types.StringValue(newDataNativePTR.Description),
-		DescriptionShort:                        types.StringValue(newDataNativePTR.DescriptionShort),
-		Guidance:                                types.StringValue(newDataNativePTR.Guidance),
-		HelmExternal:                            types.StringValue(newDataNativePTR.HelmExternal),
-		Icon:                                    types.StringValue(newDataNativePTR.Icon),
-		Id:                                      types.StringValue(newDataNativePTR.Id),
-		LicenseFee:                              types.StringValue(newDataNativePTR.LicenseFee),
-		LicenseInfo:                             types.StringValue(newDataNativePTR.LicenseInfo),
+		Description:                             util.StringSetOrNull(newDataNativePTR.Description),
+		DescriptionShort:                        util.StringSetOrNull(newDataNativePTR.DescriptionShort),
+		Guidance:                                util.StringSetOrNull(newDataNativePTR.Guidance),
+		HelmExternal:                            util.StringSetOrNull(newDataNativePTR.HelmExternal),
+		Icon:                                    util.StringSetOrNull(newDataNativePTR.Icon),
+		Id:                                      util.StringSetOrNull(newDataNativePTR.Id),
+		LicenseFee:                              util.StringSetOrNull(newDataNativePTR.LicenseFee),
+		LicenseInfo:                             util.StringSetOrNull(newDataNativePTR.LicenseInfo),
 		Number:                                  types.Int64Value(newDataNativePTR.Number),
-		PostDeploymentInfo:                      types.StringValue(newDataNativePTR.PostDeploymentInfo),
-		PreDeploymentInfo:                       types.StringValue(newDataNativePTR.PreDeploymentInfo),
-		PricingInfo:                             types.StringValue(newDataNativePTR.PricingInfo),
-		ProductId:                               types.StringValue(newDataNativePTR.ProductId),
-		ProposedReleaseDate:                     types.StringValue(newDataNativePTR.ProposedReleaseDate),
-		ScheduledReleaseDate:                    types.StringValue(newDataNativePTR.ScheduledReleaseDate),
-		ScheduledReleaseUntilDate:               types.StringValue(newDataNativePTR.ScheduledReleaseUntilDate),
-		State:                                   types.StringValue(newDataNativePTR.State),
+		PostDeploymentInfo:                      util.StringSetOrNull(newDataNativePTR.PostDeploymentInfo),
+		PreDeploymentInfo:                       util.StringSetOrNull(newDataNativePTR.PreDeploymentInfo),
+		PricingInfo:                             util.StringSetOrNull(newDataNativePTR.PricingInfo),
+		ProductId:                               util.StringSetOrNull(newDataNativePTR.ProductId),
+		ProposedReleaseDate:                     util.StringSetOrNull(newDataNativePTR.ProposedReleaseDate),
+		ScheduledReleaseDate:                    util.StringSetOrNull(newDataNativePTR.ScheduledReleaseDate),
+		ScheduledReleaseUntilDate:               util.StringSetOrNull(newDataNativePTR.ScheduledReleaseUntilDate),
+		State:                                   util.StringSetOrNull(string(newDataNativePTR.State)),
 		UsedSoftware:                            softAsList,
-		Version:                                 types.StringValue(newDataNativePTR.Version),
-		Byol: ByolValue{
-			ActivationUrl:    types.StringValue(newDataNativePTR.Byol.ActivationUrl),
-			FileNameInSecret: types.StringValue(newDataNativePTR.Byol.FileNameInSecret),
-			SecretName:       types.StringValue(newDataNativePTR.Byol.SecretName),
-			WebshopUrl:       types.StringValue(newDataNativePTR.Byol.WebshopUrl),
-		},
+		Version:                                 util.StringSetOrNull(newDataNativePTR.Version),
+		Byol:                                    byolObj,
 	}
 
 	return &newData, nil
 }
 
 func (r *productRevisionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data ProductRevisionModel
+	var data productRevisionResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
-	tflog.Debug(ctx, fmt.Sprintf("read req data: %+v", data))
+	tflog.Debug(ctx, fmt.Sprintf("reading product revision %s", data.Id.ValueString()))
 
 	if data.Id.IsNull() || data.Id.IsUnknown() || data.Id.ValueString() == "" {
 		resp.Diagnostics.AddError(
@@ -349,32 +252,64 @@ func (r *productRevisionResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	url := fmt.Sprintf("%s/%s", productRevisionResourcePath, util.SanitizeString(data.Id.ValueString()))
-	newDataNativePTR, err := util.MakePRMarketplaceRequest[ProductRevisionResourceNativeModel](ctx, http.MethodGet, url, nil, r.client) // TODO - Switch to normal one when fixed
+	dataPTR, err := r.readProductRevision(ctx, data.Id.ValueString())
+	if util.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("product revision %s no longer exists, removing it from the state", data.Id.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %v", http.MethodGet, url, nil),
+			fmt.Sprintf("Couldn't read product revision %s", data.Id.ValueString()),
 			fmt.Sprintf("error: %v", err),
 		)
 		return
 	}
 
-	dataPTR, err := ProductRevisionMapper(ctx, newDataNativePTR)
-	if err != nil {
-		resp.Diagnostics.AddError("Couldn't map response to product resource", fmt.Sprintf("error: %v", err))
-		return
-	}
+	normalizeProductRevision(ctx, data.ProductRevisionModel, dataPTR)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, dataPTR)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data.withFiles(dataPTR))...)
+}
+
+// readProductRevision fetches the server's representation of the revision
+func (r *productRevisionResource) readProductRevision(ctx context.Context, id string) (*ProductRevisionModel, error) {
+	newDataNativePTR, err := r.client.GetRevision(ctx, util.SanitizeString(id))
+	if err != nil {
+		return nil, err
+	}
+
+	dataPTR, err := ProductRevisionMapper(ctx, newDataNativePTR)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't map response to product revision resource: %w", err)
+	}
+	return dataPTR, nil
+}
+
+// readProductRevisionAfterWrite reads the revision back after it was created or updated. If that fails, the response of
+// the write is used instead, so the revision isn't lost from the state.
+func (r *productRevisionResource) readProductRevisionAfterWrite(ctx context.Context, id string, written *sellerapi.ProductRevision, diags *diag.Diagnostics) (*ProductRevisionModel, error) {
+	dataPTR, err := r.readProductRevision(ctx, id)
+	if err == nil {
+		return dataPTR, nil
+	}
+
+	diags.AddWarning(
+		fmt.Sprintf("Couldn't read product revision %s back", id),
+		fmt.Sprintf("Using the response of the write instead, run `terraform apply -refresh-only` if the next plan shows changes.\nerror: %v", err),
+	)
+	if written.Id == "" {
+		written.Id = id
+	}
+	return ProductRevisionMapper(ctx, written)
 }
 
 func (r *productRevisionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data ProductRevisionModel
+	var data productRevisionResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -385,61 +320,44 @@ func (r *productRevisionResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	body, err := productRevisionModMapper(ctx, data)
+	revision, err := productRevisionModMapper(ctx, data.ProductRevisionModel)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Couldn't map plan data into ProductRevisionMod struct", fmt.Sprintf("err: %v", err))
+			"Couldn't map plan data into a product revision", fmt.Sprintf("err: %v", err))
+		return
+	}
+	resp.Diagnostics.Append(data.addFiles(ctx, revision)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	newProductPTR, err := util.MakePRMarketplaceRequest[ProductRevisionResourceNativeModel](ctx, http.MethodPost, productRevisionResourcePath, bytes.NewReader(body), r.client) // TODO - switch to normal one when fixed
+	newProductPTR, err := r.client.CreateRevision(ctx, *revision)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %s", http.MethodPost, productRevisionResourcePath, body),
+			fmt.Sprintf("Couldn't create a revision of product %s", revision.ProductId),
 			fmt.Sprintf("error: %v", err),
 		)
 		return
 	}
 
-	// Set computed vars to null (new fetch required anyway)
-	data = ProductRevisionModel{
-		AdminSuggestion:                         util.StringSetOrNull(data.AdminSuggestion.ValueString()),
-		Byol:                                    byolSetOrNull(data.Byol),
-		Categories:                              util.ListSetOrNull(data.Categories, ContractualDocumentsInfoValue{}.Type(ctx)),
-		ContractualDocuments:                    util.ListSetOrNull(data.ContractualDocuments, ContractualDocumentsValue{}.Type(ctx)),
-		ContractualDocumentsInfo:                util.ListSetOrNull(data.ContractualDocumentsInfo, ContractualDocumentsInfoValue{}.Type(ctx)),
-		Description:                             util.StringSetOrNull(data.Description.ValueString()),
-		DescriptionShort:                        util.StringSetOrNull(data.DescriptionShort.ValueString()),
-		Eula:                                    util.StringSetOrNull(data.Eula.ValueString()),
-		Guidance:                                util.StringSetOrNull(data.Guidance.ValueString()),
-		HelmExternal:                            util.StringSetOrNull(data.HelmExternal.ValueString()),
-		Icon:                                    util.StringSetOrNull(data.Icon.ValueString()),
-		Id:                                      util.StringSetOrNull(newProductPTR.Id), // The only thing that's updated from backend call as other fields are expected to change - don't want inconsistent state errors
-		LicenseFee:                              util.StringSetOrNull(data.LicenseFee.ValueString()),
-		LicenseInfo:                             util.StringSetOrNull(data.LicenseInfo.ValueString()),
-		Number:                                  types.Int64Value(data.Number.ValueInt64()), // Uninitialized ints have a value of 0, so this shouldn't ever be null/unknown
-		PostDeploymentInfo:                      util.StringSetOrNull(data.PostDeploymentInfo.ValueString()),
-		PreDeploymentInfo:                       util.StringSetOrNull(data.PreDeploymentInfo.ValueString()),
-		PricingInfo:                             util.StringSetOrNull(data.PricingInfo.ValueString()),
-		ProductId:                               util.StringSetOrNull(data.ProductId.ValueString()),
-		ProductRevisionApplicationConfiguration: util.ListSetOrNull(data.ProductRevisionApplicationConfiguration, ProductRevisionApplicationConfigurationValue{}.Type(ctx)),
-		ProposedReleaseDate:                     util.StringSetOrNull(data.ProposedReleaseDate.ValueString()),
-		ScheduledReleaseDate:                    util.StringSetOrNull(data.ScheduledReleaseDate.ValueString()),
-		ScheduledReleaseUntilDate:               util.StringSetOrNull(data.ScheduledReleaseUntilDate.ValueString()),
-		State:                                   util.StringSetOrNull(data.State.ValueString()),
-		UsedSoftware:                            util.ListSetOrNull(data.UsedSoftware, UsedSoftwareValue{}.Type(ctx)),
-		Version:                                 util.StringSetOrNull(data.Version.ValueString()),
+	// Read the revision back, as the marketplace fills in the server-managed attributes
+	dataPTR, err := r.readProductRevisionAfterWrite(ctx, newProductPTR.Id, newProductPTR, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError("Couldn't map response to product revision resource", fmt.Sprintf("error: %v", err))
+		return
 	}
 
+	normalizeProductRevision(ctx, data.ProductRevisionModel, dataPTR)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-	resp.Diagnostics.AddWarning("Expecting state drift", "Read required after Create. Run `terraform apply -refresh-only` now.")
+	resp.Diagnostics.Append(resp.State.Set(ctx, data.withFiles(dataPTR))...)
 }
 
-func productRevisionModMapper(ctx context.Context, data ProductRevisionModel) ([]byte, error) {
+// productRevisionModMapper returns the revision to send for the plan, only with the attributes the seller can set
+func productRevisionModMapper(ctx context.Context, data ProductRevisionModel) (*sellerapi.ProductRevision, error) {
 	var tempCategories []string
 	if !data.Categories.IsUnknown() { // TODO - only checking these for unknown what if it's null
 		diags := data.Categories.ElementsAs(ctx, &tempCategories, false)
@@ -448,31 +366,31 @@ func productRevisionModMapper(ctx context.Context, data ProductRevisionModel) ([
 		}
 	}
 
-	var tempUsedSoft []productRevisionResourceUsedSoftwareNativeModel
+	var tempUsedSoft []sellerapi.UsedSoftware
 	if !data.UsedSoftware.IsUnknown() {
 		diags := data.UsedSoftware.ElementsAs(ctx, &tempUsedSoft, false)
 		if diags.HasError() {
-			return nil, errors.New(fmt.Sprintf("couldn't convert data software to list of productRevisionResourceUsedSoftwareNativeModel: elements: %v, tempUsedSoft: %v, err: %+v", data.UsedSoftware.Elements(), tempUsedSoft, diags.Errors()))
+			return nil, errors.New(fmt.Sprintf("couldn't convert data software to list of UsedSoftware: elements: %v, tempUsedSoft: %v, err: %+v", data.UsedSoftware.Elements(), tempUsedSoft, diags.Errors()))
 		}
 	}
 
-	var tempConfig []ProductRevisionResourceConfigNativeModel
+	var tempConfig []sellerapi.ConfigurationTemplate
 	if !data.ProductRevisionApplicationConfiguration.IsUnknown() {
 		diags := data.ProductRevisionApplicationConfiguration.ElementsAs(ctx, &tempConfig, false)
 		if diags.HasError() {
-			return nil, errors.New(fmt.Sprintf("couldn't convert configs to list of productRevisionResourceConfigNativeModel: elements: %v, tempUsedSoft: %v, err: %+v", data.ProductRevisionApplicationConfiguration.Elements(), tempConfig, diags.Errors()))
+			return nil, errors.New(fmt.Sprintf("couldn't convert configs to list of ConfigurationTemplate: elements: %v, tempUsedSoft: %v, err: %+v", data.ProductRevisionApplicationConfiguration.Elements(), tempConfig, diags.Errors()))
 		}
 	}
 
-	var tempContDocs []ContractualDocsNativeModel
+	var tempContDocs []sellerapi.ContractualDocument
 	if !data.ContractualDocuments.IsUnknown() {
 		diags := data.ContractualDocuments.ElementsAs(ctx, &tempContDocs, false)
 		if diags.HasError() {
-			return nil, errors.New(fmt.Sprintf("couldn't convert docs to list of contractualDocsNativeModel: elements: %v, tempUsedSoft: %v, err: %+v", data.ContractualDocuments.Elements(), tempContDocs, diags.Errors()))
+			return nil, errors.New(fmt.Sprintf("couldn't convert docs to list of ContractualDocument: elements: %v, tempUsedSoft: %v, err: %+v", data.ContractualDocuments.Elements(), tempContDocs, diags.Errors()))
 		}
 	}
 
-	body, err := json.Marshal(productRevisionResourceNativeModModel{
+	return &sellerapi.ProductRevision{
 		Categories:           tempCategories,
 		ProductId:            data.ProductId.ValueString(),
 		Description:          data.Description.ValueString(),
@@ -490,16 +408,12 @@ func productRevisionModMapper(ctx context.Context, data ProductRevisionModel) ([
 		UsedSoftware:         tempUsedSoft,
 		Configuration:        tempConfig,
 		ContractualDocuments: tempContDocs,
-	})
-	if err != nil {
-		return nil, errors.New(fmt.Sprintf("couldn't marshall product into json: %v", err))
-	}
-	return body, err
+	}, nil
 }
 
 func (r *productRevisionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ProductRevisionModel
-	var priorState ProductRevisionModel
+	var data productRevisionResourceModel
+	var priorState productRevisionResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
 
@@ -514,73 +428,57 @@ func (r *productRevisionResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	body, err := productRevisionModMapper(ctx, data)
+	revision, err := productRevisionModMapper(ctx, data.ProductRevisionModel)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Couldn't map plan data into ProductRevisionMod struct", fmt.Sprintf("err: %v", err))
+			"Couldn't map plan data into a product revision", fmt.Sprintf("err: %v", err))
+		return
+	}
+	resp.Diagnostics.Append(data.addFiles(ctx, revision)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	url := fmt.Sprintf("%s/%s", productRevisionResourcePath, util.SanitizeString(data.Id.ValueString()))
-	newProductPTR, err := util.MakePRMarketplaceRequest[ProductRevisionResourceNativeModel](ctx, http.MethodPatch, url, bytes.NewReader(body), r.client) // TODO - switch to the normal one when fixed
+	newProductPTR, err := r.client.EditRevision(ctx, util.SanitizeString(data.Id.ValueString()), *revision)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %s", http.MethodPatch, url, body),
+			fmt.Sprintf("Couldn't update product revision %s", data.Id.ValueString()),
 			fmt.Sprintf("error: %v", err),
 		)
 		return
 	}
 
-	// Remove Unknown
-	data = ProductRevisionModel{
-		AdminSuggestion:                         util.StringSetOrNull(data.AdminSuggestion.ValueString()),
-		Byol:                                    byolSetOrNull(data.Byol),
-		Categories:                              util.ListSetOrNull(data.Categories, ContractualDocumentsInfoValue{}.Type(ctx)),
-		ContractualDocuments:                    util.ListSetOrNull(data.ContractualDocuments, ContractualDocumentsValue{}.Type(ctx)),
-		ContractualDocumentsInfo:                util.ListSetOrNull(data.ContractualDocumentsInfo, ContractualDocumentsInfoValue{}.Type(ctx)),
-		Description:                             util.StringSetOrNull(data.Description.ValueString()),
-		DescriptionShort:                        util.StringSetOrNull(data.DescriptionShort.ValueString()),
-		Eula:                                    util.StringSetOrNull(data.Eula.ValueString()),
-		Guidance:                                util.StringSetOrNull(data.Guidance.ValueString()),
-		HelmExternal:                            util.StringSetOrNull(data.HelmExternal.ValueString()),
-		Icon:                                    util.StringSetOrNull(data.Icon.ValueString()),
-		Id:                                      util.StringSetOrNull(newProductPTR.Id), // The only thing that's updated from backend call as other fields are expected to change - don't want inconsistent state errors
-		LicenseFee:                              util.StringSetOrNull(data.LicenseFee.ValueString()),
-		LicenseInfo:                             util.StringSetOrNull(data.LicenseInfo.ValueString()),
-		Number:                                  types.Int64Value(data.Number.ValueInt64()), // Uninitialized ints have a value of 0
-		PostDeploymentInfo:                      util.StringSetOrNull(data.PostDeploymentInfo.ValueString()),
-		PreDeploymentInfo:                       util.StringSetOrNull(data.PreDeploymentInfo.ValueString()),
-		PricingInfo:                             util.StringSetOrNull(data.PricingInfo.ValueString()),
-		ProductId:                               util.StringSetOrNull(data.ProductId.ValueString()),
-		ProductRevisionApplicationConfiguration: util.ListSetOrNull(data.ProductRevisionApplicationConfiguration, ProductRevisionApplicationConfigurationValue{}.Type(ctx)),
-		ProposedReleaseDate:                     util.StringSetOrNull(data.ProposedReleaseDate.ValueString()),
-		ScheduledReleaseDate:                    util.StringSetOrNull(data.ScheduledReleaseDate.ValueString()),
-		ScheduledReleaseUntilDate:               util.StringSetOrNull(data.ScheduledReleaseUntilDate.ValueString()),
-		State:                                   util.StringSetOrNull(data.State.ValueString()),
-		UsedSoftware:                            util.ListSetOrNull(data.UsedSoftware, UsedSoftwareValue{}.Type(ctx)),
-		Version:                                 util.StringSetOrNull(data.Version.ValueString()),
+	dataPTR, err := r.readProductRevisionAfterWrite(ctx, data.Id.ValueString(), newProductPTR, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError("Couldn't map response to product revision resource", fmt.Sprintf("error: %v", err))
+		return
 	}
 
+	normalizeProductRevision(ctx, data.ProductRevisionModel, dataPTR)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-	tflog.Warn(ctx, fmt.Sprintf("Read required after Update. Run `terraform apply -refresh-only` now."))
+	resp.Diagnostics.Append(resp.State.Set(ctx, data.withFiles(dataPTR))...)
 }
 
 func (r *productRevisionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data ProductRevisionModel
+	var data productRevisionResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
-	url := fmt.Sprintf("%s/%s", productRevisionResourcePath, util.SanitizeString(data.Id.ValueString()))
-	_, err := util.MakeMarketplaceRequest[struct{}](ctx, http.MethodDelete, url, nil, r.client)
+	err := r.client.DeleteRevision(ctx, util.SanitizeString(data.Id.ValueString()))
+	if util.IsNotFound(err) {
+		// Already gone, e.g. deleted in the seller dashboard
+		tflog.Info(ctx, fmt.Sprintf("product revision %s was already deleted", data.Id.ValueString()))
+		err = nil
+	}
 	if err != nil {
 		resp.Diagnostics.AddWarning(
-			fmt.Sprintf("Couldn't send %s to %s with a body of %v", http.MethodDelete, url, nil),
+			fmt.Sprintf("Couldn't delete product revision %s", data.Id.ValueString()),
 			fmt.Sprintf("ignoring error in the hope the parent product will be deleted later as part of the plan."+
 				"\nif this resource was to be replaced, please recreate its parent.\nerror: %v", err),
 		)
@@ -593,10 +491,3 @@ func (r *productRevisionResource) Delete(ctx context.Context, req resource.Delet
 
 	resp.State.RemoveResource(ctx)
 }
-
-func byolSetOrNull(b ByolValue) ByolValue {
-	if b.IsNull() || b.IsUnknown() {
-		return NewByolValueNull()
-	}
-	return b
-}
@@ -0,0 +1,306 @@
+package resource_product_revision
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-otc-marketplace/internal/sellerapi"
+	"terraform-provider-otc-marketplace/internal/util"
+	"time"
+)
+
+const (
+	defaultSubmissionCreateTimeout = 60 * time.Minute
+	reviewPollInterval             = 30 * time.Second
+)
+
+var _ resource.Resource = (*productRevisionSubmissionResource)(nil)
+var _ resource.ResourceWithImportState = (*productRevisionSubmissionResource)(nil)
+
+// NewProductRevisionSubmissionResource sends a product revision to review. Revisions are always created as drafts,
+// the review is a separate step so it can be ordered after everything the revision depends on.
+func NewProductRevisionSubmissionResource() resource.Resource {
+	return &productRevisionSubmissionResource{}
+}
+
+type productRevisionSubmissionResource struct {
+	client *sellerapi.Client
+}
+
+type productRevisionSubmissionModel struct {
+	Id                types.String   `tfsdk:"id"`
+	ProductRevisionId types.String   `tfsdk:"product_revision_id"`
+	WaitForApproval   types.Bool     `tfsdk:"wait_for_approval"`
+	State             types.String   `tfsdk:"state"`
+	AdminSuggestion   types.String   `tfsdk:"admin_suggestion"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *productRevisionSubmissionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_product_revision_submission"
+}
+
+func (r *productRevisionSubmissionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Sends a product revision to review by setting its state to `ready_for_review`. If the revision is " +
+			"rejected or changed back to a `draft`, it's sent to review again on the next apply.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "NanoID of the submitted product revision",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"product_revision_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "NanoID of the product revision to send to review",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"wait_for_approval": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Wait until the revision is approved, the apply fails if it's rejected instead",
+			},
+			"state": schema.StringAttribute{
+				Computed:      true,
+				Description:   "State of the review, either `ready_for_review`, `approved` or `rejected`",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"admin_suggestion": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Why the marketplace rejected the revision and what to change",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create:            true,
+				CreateDescription: fmt.Sprintf("How long to wait for the review if `wait_for_approval` is set. Defaults to `%s`.", defaultSubmissionCreateTimeout),
+			}),
+		},
+	}
+}
+
+func (r *productRevisionSubmissionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// IMPORTANT: This method is called MULTIPLE times. An initial call might not have configured the Provider yet, so we need
+		// to handle this gracefully. It will eventually be called with a configured provider.
+		return
+	}
+
+	clientPTR, ok := req.ProviderData.(*sellerapi.Client)
+	if !ok || clientPTR == nil {
+		resp.Diagnostics.AddError(
+			"Provider Configuration Error",
+			"The provider was not configured correctly, or the API client is missing.",
+		)
+		return
+	}
+	r.client = clientPTR
+}
+
+// ImportState imports the submission of a product revision by the NanoID of the revision
+func (r *productRevisionSubmissionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	util.ImportStateByNanoID(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("product_revision_id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_approval"), false)...)
+}
+
+func (r *productRevisionSubmissionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data productRevisionSubmissionModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ProductRevisionId.ValueString()
+	revision, err := r.client.GetRevision(ctx, util.SanitizeString(id))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Couldn't read product revision %s", id),
+			fmt.Sprintf("error: %v", err),
+		)
+		return
+	}
+
+	// A revision that is already under review or approved doesn't have to be submitted again
+	if revision.State == sellerapi.RevisionStateDraft || revision.State == sellerapi.RevisionStateRejected {
+		revision, err = r.client.EditRevision(ctx, util.SanitizeString(id), sellerapi.ProductRevision{State: sellerapi.RevisionStateReadyForReview})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Couldn't send product revision %s to review", id),
+				fmt.Sprintf("error: %v", err),
+			)
+			return
+		}
+		tflog.Info(ctx, fmt.Sprintf("sent product revision %s to review", id))
+	}
+
+	var waitErr error
+	if data.WaitForApproval.ValueBool() {
+		createTimeout, diags := data.Timeouts.Create(ctx, defaultSubmissionCreateTimeout)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var reviewed *sellerapi.ProductRevision
+		reviewed, waitErr = r.waitForReview(ctx, id, createTimeout)
+		if reviewed != nil {
+			revision = reviewed
+		}
+	}
+
+	if revision.State == sellerapi.RevisionStateRejected {
+		// Not saved, so the revision is submitted again on the next apply
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Product revision %s was rejected", id),
+			fmt.Sprintf("Change the revision as suggested and apply again to send it to review again.\nadmin suggestion: %s", adminSuggestionOrDefault(revision.AdminSuggestion)),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(id)
+	data.setReview(revision)
+
+	// Save data into Terraform state, even if the review timed out, so the submission is tainted and waited for again
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if waitErr != nil {
+		resp.Diagnostics.AddError("Product revision wasn't reviewed", waitErr.Error())
+	}
+}
+
+func (r *productRevisionSubmissionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data productRevisionSubmissionModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ProductRevisionId.ValueString()
+	revision, err := r.client.GetRevision(ctx, util.SanitizeString(id))
+	if util.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("product revision %s no longer exists, removing its submission from the state", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Couldn't read product revision %s", id),
+			fmt.Sprintf("error: %v", err),
+		)
+		return
+	}
+
+	switch revision.State {
+	case sellerapi.RevisionStateRejected:
+		resp.Diagnostics.AddWarning(
+			fmt.Sprintf("Product revision %s was rejected", id),
+			fmt.Sprintf("It will be sent to review again on the next apply.\nadmin suggestion: %s", adminSuggestionOrDefault(revision.AdminSuggestion)),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	case sellerapi.RevisionStateDraft:
+		tflog.Warn(ctx, fmt.Sprintf("product revision %s is a draft again, e.g. because it was changed, it will be sent to review again", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.setReview(revision)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only changes wait_for_approval or the timeouts, which take effect on the next submission
+func (r *productRevisionSubmissionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data productRevisionSubmissionModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only removes the submission from the state, the marketplace can't withdraw a revision from review
+func (r *productRevisionSubmissionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data productRevisionSubmissionModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("product revision %s stays %s, a review can't be withdrawn", data.ProductRevisionId.ValueString(), data.State.ValueString()))
+	resp.State.RemoveResource(ctx)
+}
+
+func (m *productRevisionSubmissionModel) setReview(revision *sellerapi.ProductRevision) {
+	m.State = types.StringValue(string(revision.State))
+	m.AdminSuggestion = types.StringValue(revision.AdminSuggestion)
+}
+
+// waitForReview polls the revision until it's approved or rejected. The last response is returned together with the
+// error if the timeout is reached.
+func (r *productRevisionSubmissionResource) waitForReview(ctx context.Context, id string, timeout time.Duration) (*sellerapi.ProductRevision, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(reviewPollInterval)
+	defer ticker.Stop()
+
+	var latest *sellerapi.ProductRevision
+	for {
+		revision, err := r.client.GetRevision(ctx, util.SanitizeString(id))
+		if err != nil {
+			// A request cut off by the timeout is reported as a timeout below
+			if ctx.Err() == nil {
+				return latest, err
+			}
+		} else {
+			latest = revision
+			switch revision.State {
+			case sellerapi.RevisionStateApproved, sellerapi.RevisionStateRejected:
+				return revision, nil
+			}
+			tflog.Debug(ctx, fmt.Sprintf("product revision %s is %s, waiting for the review", id, revision.State))
+		}
+
+		select {
+		case <-ctx.Done():
+			lastState := "unknown"
+			if latest != nil {
+				lastState = string(latest.State)
+			}
+			return latest, fmt.Errorf("timed out after %s waiting for product revision %s to be approved, last state: %s", timeout, id, lastState)
+		case <-ticker.C:
+		}
+	}
+}
+
+func adminSuggestionOrDefault(adminSuggestion string) string {
+	if adminSuggestion == "" {
+		return "the marketplace didn't give a reason"
+	}
+	return adminSuggestion
+}
@@ -0,0 +1,45 @@
+package sellerapi
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	whoAmIPath       = "/whoami"
+	profilePath      = "/profiles/profile"
+	logoutPath       = "/logout"
+	salesHistoryPath = "/sales-history"
+	categoriesPath   = "/categories"
+)
+
+// Login obtains a new token, requests log in on their own when needed
+func (c *Client) Login(ctx context.Context) error {
+	return c.api.Login(ctx)
+}
+
+// Logout revokes the OTC token
+func (c *Client) Logout(ctx context.Context) error {
+	_, err := do[struct{}](ctx, c, http.MethodGet, logoutPath, nil, nil)
+	return err
+}
+
+func (c *Client) WhoAmI(ctx context.Context) (*WhoAmI, error) {
+	return do[WhoAmI](ctx, c, http.MethodGet, whoAmIPath, nil, nil)
+}
+
+func (c *Client) GetProfile(ctx context.Context) (*Profile, error) {
+	return do[Profile](ctx, c, http.MethodGet, profilePath, nil, nil)
+}
+
+func (c *Client) ListSalesHistory(ctx context.Context) ([]Sale, error) {
+	return list[Sale](ctx, c, salesHistoryPath, nil)
+}
+
+func (c *Client) ListCategories(ctx context.Context) ([]Category, error) {
+	return list[Category](ctx, c, categoriesPath, nil)
+}
+
+func (c *Client) GetCategory(ctx context.Context, id string) (*Category, error) {
+	return do[Category](ctx, c, http.MethodGet, byId(categoriesPath, id), nil, nil)
+}
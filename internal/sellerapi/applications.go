@@ -0,0 +1,25 @@
+package sellerapi
+
+import (
+	"context"
+	"net/http"
+)
+
+const applicationsPath = "/applications"
+
+func (c *Client) ListApplications(ctx context.Context) ([]Application, error) {
+	return list[Application](ctx, c, applicationsPath, nil)
+}
+
+// CreateApplication starts the deployment, the application is `pending` until it's finished
+func (c *Client) CreateApplication(ctx context.Context, application ApplicationInput) (*Application, error) {
+	return do[Application](ctx, c, http.MethodPost, applicationsPath, nil, application)
+}
+
+func (c *Client) GetApplication(ctx context.Context, id string) (*Application, error) {
+	return do[Application](ctx, c, http.MethodGet, byId(applicationsPath, id), nil, nil)
+}
+
+func (c *Client) DeleteApplication(ctx context.Context, id string) error {
+	return deleteById(ctx, c, applicationsPath, id)
+}
@@ -0,0 +1,77 @@
+// Package sellerapi is a typed client for the seller API described in openapi.yml, with one method per operationId.
+package sellerapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"terraform-provider-otc-marketplace/internal/util"
+)
+
+// Client sends the requests through the authenticated util.MarketplaceAPIClient, so it shares its token, retries and
+// error handling. Errors of the marketplace are returned as *util.APIError.
+type Client struct {
+	api *util.MarketplaceAPIClient
+}
+
+func NewClient(api *util.MarketplaceAPIClient) *Client {
+	return &Client{api: api}
+}
+
+// API returns the underlying client, e.g. to check the token
+func (c *Client) API() *util.MarketplaceAPIClient {
+	return c.api
+}
+
+// do sends body as JSON, if it's not nil, and decodes the response into T
+func do[T any](ctx context.Context, c *Client, method string, path string, query url.Values, body any) (*T, error) {
+	reqBody, err := encode(body)
+	if err != nil {
+		return nil, err
+	}
+	return util.MakeMarketplaceRequest[T](ctx, method, withQuery(path, query), reqBody, c.api)
+}
+
+func encode(body any) (io.Reader, error) {
+	if body == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal request body: %w", err)
+	}
+	return bytes.NewReader(b), nil
+}
+
+func withQuery(path string, query url.Values) string {
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
+// byId returns the path of a single object of a collection, e.g. /products/{id}
+func byId(collection string, id string) string {
+	return fmt.Sprintf("%s/%s", collection, url.PathEscape(id))
+}
+
+// list returns an empty slice instead of nil if there are no objects
+func list[T any](ctx context.Context, c *Client, path string, query url.Values) ([]T, error) {
+	objects, err := do[[]T](ctx, c, http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	if *objects == nil {
+		return []T{}, nil
+	}
+	return *objects, nil
+}
+
+func deleteById(ctx context.Context, c *Client, collection string, id string) error {
+	_, err := do[struct{}](ctx, c, http.MethodDelete, byId(collection, id), nil, nil)
+	return err
+}
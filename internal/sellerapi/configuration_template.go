@@ -0,0 +1,54 @@
+package sellerapi
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ValidateValue returns why the value isn't allowed by the template, if at all
+func (t ConfigurationTemplate) ValidateValue(value string) []string {
+	var problems []string
+
+	switch t.InputType {
+	case InputTypeSwitch:
+		if value != "true" && value != "false" {
+			problems = append(problems, fmt.Sprintf("A switch has to be true or false, got %q.", value))
+		}
+	case InputTypeSelection:
+		options := make([]string, 0, len(t.Values))
+		for _, option := range t.Values {
+			options = append(options, option.Value)
+		}
+
+		// Multiple selected options are separated by commas
+		selected := []string{value}
+		if t.Multiple {
+			selected = strings.Split(value, ",")
+		}
+		for _, option := range selected {
+			option = strings.TrimSpace(option)
+			if !slices.Contains(options, option) {
+				problems = append(problems, fmt.Sprintf("%q isn't one of the options: %s.", option, strings.Join(options, ", ")))
+			}
+		}
+	}
+
+	for _, validation := range t.Validation {
+		pattern, err := regexp.Compile(validation.Pattern)
+		if err != nil {
+			// The pattern is meant for the seller dashboard, which may support syntax Go doesn't
+			continue
+		}
+		if !pattern.MatchString(value) {
+			message := validation.Message
+			if message == "" {
+				message = fmt.Sprintf("The value has to match %s.", validation.Pattern)
+			}
+			problems = append(problems, message)
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,49 @@
+package sellerapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// DefaultValue of a ConfigurationTemplate. The marketplace uses a bool for a `switch` and a string for every other
+// input type, the provider always uses the string, e.g. "true".
+type DefaultValue string
+
+// UnmarshalJSON accepts a string or a bool
+func (v *DefaultValue) UnmarshalJSON(b []byte) error {
+	var value any
+	if err := json.Unmarshal(b, &value); err != nil {
+		return err
+	}
+
+	switch value := value.(type) {
+	case nil:
+		*v = ""
+	case string:
+		*v = DefaultValue(value)
+	case bool:
+		*v = DefaultValue(strconv.FormatBool(value))
+	default:
+		return fmt.Errorf("default_value must be a string or a bool, got %s", b)
+	}
+	return nil
+}
+
+// MarshalJSON sends the default value of a switch as a bool
+func (t ConfigurationTemplate) MarshalJSON() ([]byte, error) {
+	// plain doesn't have the methods of ConfigurationTemplate, so it's marshalled as usual
+	type plain ConfigurationTemplate
+	if t.InputType != InputTypeSwitch || t.DefaultValue == "" {
+		return json.Marshal(plain(t))
+	}
+
+	value, err := strconv.ParseBool(string(t.DefaultValue))
+	if err != nil {
+		return nil, fmt.Errorf("default_value of switch %s must be true or false, got %q", t.Key, t.DefaultValue)
+	}
+	return json.Marshal(struct {
+		plain
+		DefaultValue bool `json:"default_value"`
+	}{plain(t), value})
+}
@@ -0,0 +1,58 @@
+package sellerapi
+
+type ProductType string
+
+const ProductTypeContainer ProductType = "container"
+
+type LicenseType string
+
+const (
+	LicenseTypeOpensource LicenseType = "opensource"
+	LicenseTypeFree       LicenseType = "free"
+	LicenseTypeTrial      LicenseType = "trial"
+	LicenseTypeByol       LicenseType = "byol"
+)
+
+// ProductState is whether the product is shown in the marketplace
+type ProductState string
+
+const (
+	ProductStatePublished   ProductState = "published"
+	ProductStateDePublished ProductState = "de-published"
+)
+
+// RevisionState starts as `draft`, sending it to review sets it to `ready_for_review` until it's approved or rejected
+type RevisionState string
+
+const (
+	RevisionStateDraft          RevisionState = "draft"
+	RevisionStateReadyForReview RevisionState = "ready_for_review"
+	RevisionStateRejected       RevisionState = "rejected"
+	RevisionStateApproved       RevisionState = "approved"
+)
+
+// ApplicationState starts as `pending` until the deployment is either `ready` or failed with an `error`
+type ApplicationState string
+
+const (
+	ApplicationStatePending ApplicationState = "pending"
+	ApplicationStateReady   ApplicationState = "ready"
+	ApplicationStateError   ApplicationState = "error"
+)
+
+// InputType of a configuration template, a selection may be shown as radio buttons, a select or checkboxes
+type InputType string
+
+const (
+	InputTypeText      InputType = "text"
+	InputTypeSwitch    InputType = "switch"
+	InputTypeSelection InputType = "selection"
+)
+
+// State of sellers and categories
+type State string
+
+const (
+	StateActive    State = "active"
+	StateSuspended State = "suspended"
+)
@@ -0,0 +1,228 @@
+package sellerapi
+
+// The models follow the schemas in openapi.yml. Where the backend differs from the spec, the backend wins and the
+// field says so. Models that are read from list attributes with ElementsAs also carry tfsdk tags.
+
+type WhoAmI struct {
+	DomainName    string `json:"domain_name,omitempty"`
+	Username      string `json:"username,omitempty"`
+	LastProjectId string `json:"last_project_id,omitempty"`
+	LlmHub        bool   `json:"llm_hub,omitempty"`
+}
+
+type Profile struct {
+	Id                        string `json:"id,omitempty"`
+	Name                      string `json:"name,omitempty"`
+	Description               string `json:"description,omitempty"`
+	Email                     string `json:"email,omitempty"`
+	Status                    string `json:"status,omitempty"`
+	SupportEmail              string `json:"support_email,omitempty"`
+	SupportUrl                string `json:"support_url,omitempty"`
+	CustomerSupportNumber     string `json:"customer_support_number,omitempty"`
+	TempName                  string `json:"temp_name,omitempty"`
+	TempDescription           string `json:"temp_description,omitempty"`
+	TempEmail                 string `json:"temp_email,omitempty"`
+	TempSupportEmail          string `json:"temp_support_email,omitempty"`
+	TempSupportUrl            string `json:"temp_support_url,omitempty"`
+	TempCustomerSupportNumber string `json:"temp_customer_support_number,omitempty"`
+}
+
+type Project struct {
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type Cluster struct {
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type Namespace struct {
+	Name      string `json:"name,omitempty"`
+	ClusterId string `json:"cluster_id,omitempty"`
+	ProjectId string `json:"project_id,omitempty"`
+}
+
+type Sale struct {
+	ProductRevisionId     string `json:"product_revision_id,omitempty"`
+	ProductId             string `json:"product_id,omitempty"`
+	ProductName           string `json:"product_name,omitempty"`
+	CustomerCompanyName   string `json:"customer_company_name,omitempty"`
+	CustomerCompanyUrl    string `json:"customer_company_url,omitempty"`
+	CustomerContactNumber string `json:"customer_contact_number,omitempty"`
+	CustomerContactEmail  string `json:"customer_contact_email,omitempty"`
+	DeployedAt            string `json:"deployed_at,omitempty"`
+}
+
+type Category struct {
+	Id          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Position    int64  `json:"position,omitempty"`
+	State       State  `json:"state,omitempty"`
+}
+
+type Seller struct {
+	Id           string `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+	State        State  `json:"state,omitempty"`
+	SupportEmail string `json:"support_email,omitempty"`
+	SupportUrl   string `json:"support_url,omitempty"`
+}
+
+type LlmHub struct {
+	ExternalApi string `json:"external_api,omitempty"`
+}
+
+// Product is returned by the product endpoints
+type Product struct {
+	Id               string       `json:"id,omitempty"`
+	ActiveRevisionId string       `json:"active_revision_id,omitempty"`
+	Name             string       `json:"name,omitempty"`
+	Type             ProductType  `json:"type,omitempty"`
+	LicenseType      LicenseType  `json:"license_type,omitempty"`
+	State            ProductState `json:"state,omitempty"`
+	Eol              bool         `json:"eol,omitempty"`
+	EolDate          string       `json:"eol_date,omitempty"`
+	Seller           Seller       `json:"seller,omitempty"`
+	LlmHub           LlmHub       `json:"llm_hub,omitempty"`
+	Weight           int64        `json:"weight,omitempty"`
+	CreatedAt        string       `json:"created_at,omitempty"`
+}
+
+// ProductInput is sent to create or edit a product
+type ProductInput struct {
+	Name        string      `json:"name"`
+	Type        ProductType `json:"type"`
+	LicenseType LicenseType `json:"license_type"`
+	Weight      int64       `json:"weight"`
+	// Eol is only sent if set, the product is created without it
+	Eol *bool `json:"eol,omitempty"`
+}
+
+// ProductRevision is sent to create or edit a revision, and returned by the revision endpoints. The attributes
+// managed by the marketplace (id, number, state, ...) are ignored when sent.
+type ProductRevision struct {
+	Id                        string         `json:"id,omitempty"`
+	ProductId                 string         `json:"product_id,omitempty"`
+	Number                    int64          `json:"number,omitempty"`
+	State                     RevisionState  `json:"state,omitempty"`
+	AdminSuggestion           string         `json:"admin_suggestion,omitempty"`
+	Version                   string         `json:"version,omitempty"`
+	HelmExternal              string         `json:"helm_external,omitempty"`
+	Categories                []string       `json:"categories,omitempty"`
+	Description               string         `json:"description,omitempty"`
+	DescriptionShort          string         `json:"description_short,omitempty"`
+	Icon                      string         `json:"icon,omitempty"`
+	Eula                      string         `json:"eula,omitempty"`
+	Guidance                  string         `json:"guidance,omitempty"`
+	PreDeploymentInfo         string         `json:"pre_deployment_info,omitempty"`
+	PostDeploymentInfo        string         `json:"post_deployment_info,omitempty"`
+	LicenseFee                string         `json:"license_fee,omitempty"`
+	LicenseInfo               string         `json:"license_info,omitempty"`
+	PricingInfo               string         `json:"pricing_info,omitempty"`
+	ProposedReleaseDate       string         `json:"proposed_release_date,omitempty"`
+	ScheduledReleaseDate      string         `json:"scheduled_release_date,omitempty"`
+	ScheduledReleaseUntilDate string         `json:"scheduled_release_until_date,omitempty"`
+	UsedSoftware              []UsedSoftware `json:"used_software,omitempty"`
+	Byol                      *Byol          `json:"byol,omitempty"`
+	// The backend uses `configuration`, not `product_revision_application_configuration` as documented
+	Configuration            []ConfigurationTemplate   `json:"configuration,omitempty"`
+	ContractualDocuments     []ContractualDocument     `json:"contractual_documents,omitempty"`
+	ContractualDocumentsInfo []ContractualDocumentInfo `json:"contractual_documents_info,omitempty"`
+}
+
+type Byol struct {
+	SecretName       string `json:"secret_name,omitempty"`
+	FileNameInSecret string `json:"file_name_in_secret,omitempty"`
+	ActivationUrl    string `json:"activation_url,omitempty"`
+	WebshopUrl       string `json:"webshop_url,omitempty"`
+}
+
+type UsedSoftware struct {
+	Name        string `json:"name,omitempty" tfsdk:"name"`
+	LicenseName string `json:"license_name,omitempty" tfsdk:"license_name"`
+	LicenseUrl  string `json:"license_url,omitempty" tfsdk:"license_url"`
+}
+
+// ContractualDocument is uploaded with a revision, the marketplace returns it as a ContractualDocumentInfo
+type ContractualDocument struct {
+	FileName  string `json:"file_name,omitempty" tfsdk:"file_name"`
+	Content   string `json:"content,omitempty" tfsdk:"content"`
+	IsDeleted bool   `json:"is_deleted,omitempty" tfsdk:"is_deleted"`
+}
+
+type ContractualDocumentInfo struct {
+	FileName string `json:"file_name,omitempty" tfsdk:"file_name"`
+	Url      string `json:"url,omitempty" tfsdk:"url"`
+}
+
+// ConfigurationTemplate describes a value the customer configures when deploying the application, see
+// ApplicationConfigurationTemplate in openapi.yml
+type ConfigurationTemplate struct {
+	Key          string                    `json:"key,omitempty" tfsdk:"key"`
+	Label        string                    `json:"label,omitempty" tfsdk:"label"`
+	InputType    InputType                 `json:"input_type,omitempty" tfsdk:"input_type"`
+	DefaultValue DefaultValue              `json:"default_value,omitempty" tfsdk:"default_value"`
+	Hint         string                    `json:"hint,omitempty" tfsdk:"hint"`
+	Tooltip      string                    `json:"tooltip,omitempty" tfsdk:"tooltip"`
+	Confidential bool                      `json:"confidential,omitempty" tfsdk:"confidential"`
+	Hidden       bool                      `json:"hidden,omitempty" tfsdk:"hidden"`
+	Required     bool                      `json:"required,omitempty" tfsdk:"required"`
+	Multiple     bool                      `json:"multiple,omitempty" tfsdk:"multiple"`
+	Validation   []ConfigurationValidation `json:"validation,omitempty" tfsdk:"validation"`
+	Values       []ConfigurationOption     `json:"values,omitempty" tfsdk:"values"`
+}
+
+type ConfigurationValidation struct {
+	Pattern string `json:"pattern,omitempty" tfsdk:"pattern"`
+	Message string `json:"message,omitempty" tfsdk:"message"`
+}
+
+// ConfigurationOption is one of the values a selection can be set to
+type ConfigurationOption struct {
+	Label string `json:"label,omitempty" tfsdk:"label"`
+	Value string `json:"value,omitempty" tfsdk:"value"`
+}
+
+// ApplicationConfiguration is a value the customer set for a ConfigurationTemplate
+type ApplicationConfiguration struct {
+	Key   string `json:"key" tfsdk:"key"`
+	Value string `json:"value" tfsdk:"value"`
+}
+
+// Application is returned by the application endpoints
+type Application struct {
+	Id                string                     `json:"id,omitempty"`
+	ProjectId         string                     `json:"project_id,omitempty"`
+	ClusterId         string                     `json:"cluster_id,omitempty"`
+	Namespace         string                     `json:"namespace,omitempty"`
+	ReleaseName       string                     `json:"release_name,omitempty"`
+	Description       string                     `json:"description,omitempty"`
+	Configuration     []ApplicationConfiguration `json:"configuration,omitempty"`
+	State             ApplicationState           `json:"state,omitempty"`
+	Username          string                     `json:"username,omitempty"`
+	CreatedAt         string                     `json:"created_at,omitempty"`
+	ByolLicense       string                     `json:"byol_license,omitempty"`
+	ProductRevisionId string                     `json:"product_revision_id,omitempty"`
+	Product           Product                    `json:"product,omitempty"`
+	ProductRevision   ProductRevision            `json:"product_revision,omitempty"`
+	// The backend returns `seller`, not `application_seller` as documented
+	Seller Seller `json:"seller,omitempty"`
+	// Error explains why the deployment failed if State is `error`, it's not part of openapi.yml
+	Error string `json:"error,omitempty"`
+}
+
+// ApplicationInput is sent to deploy an application, see ApplicationApplicationRevisionCombo in openapi.yml
+type ApplicationInput struct {
+	ProductRevisionId string `json:"product_revision_id,omitempty"`
+	ProjectId         string `json:"project_id,omitempty"`
+	ClusterId         string `json:"cluster_id,omitempty"`
+	Namespace         string `json:"namespace,omitempty"`
+	ReleaseName       string `json:"release_name,omitempty"`
+	Description       string `json:"description,omitempty"`
+	ByolLicense       string `json:"byol_license,omitempty"`
+	// The backend expects `application_configuration`, not `configuration` as documented
+	Configuration []ApplicationConfiguration `json:"application_configuration,omitempty"`
+}
@@ -0,0 +1,42 @@
+package sellerapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestApplicationDecode(t *testing.T) {
+	// Shortened GET /applications/{id} response of the backend
+	body := `{
+  "id": "V1StGXR8_Z5jdHi6B-myT",
+  "project_id": "project",
+  "cluster_id": "cluster",
+  "namespace": "monitoring",
+  "release_name": "prometheus-exporter",
+  "state": "ready",
+  "product_revision_id": "3f8ZyqL0dMmWw2nVbC_1x",
+  "configuration": [{"key": "replicas", "value": "2"}],
+  "seller": {
+    "id": "Xk9LmN2pQr5sTv8wYz_1a",
+    "name": "iits consulting",
+    "state": "active",
+    "support_email": "support@example.com"
+  }
+}`
+
+	var application Application
+	if err := json.Unmarshal([]byte(body), &application); err != nil {
+		t.Fatal(err)
+	}
+
+	want := Seller{Id: "Xk9LmN2pQr5sTv8wYz_1a", Name: "iits consulting", State: StateActive, SupportEmail: "support@example.com"}
+	if application.Seller != want {
+		t.Errorf("expected seller %+v, got %+v", want, application.Seller)
+	}
+	if application.State != ApplicationStateReady {
+		t.Errorf("expected state %s, got %s", ApplicationStateReady, application.State)
+	}
+	if len(application.Configuration) != 1 || application.Configuration[0] != (ApplicationConfiguration{Key: "replicas", Value: "2"}) {
+		t.Errorf("unexpected configuration %+v", application.Configuration)
+	}
+}
@@ -0,0 +1,30 @@
+package sellerapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+const (
+	projectsPath   = "/projects"
+	clustersPath   = "/clusters"
+	namespacesPath = "/namespaces"
+)
+
+func (c *Client) ListProjects(ctx context.Context) ([]Project, error) {
+	return list[Project](ctx, c, projectsPath, nil)
+}
+
+func (c *Client) GetProject(ctx context.Context, id string) (*Project, error) {
+	return do[Project](ctx, c, http.MethodGet, byId(projectsPath, id), nil, nil)
+}
+
+// ListClusters lists the CCE clusters of an OTC project
+func (c *Client) ListClusters(ctx context.Context, projectId string) ([]Cluster, error) {
+	return list[Cluster](ctx, c, clustersPath, url.Values{"project_id": {projectId}})
+}
+
+func (c *Client) ListNamespaces(ctx context.Context, projectId string, clusterId string) ([]Namespace, error) {
+	return list[Namespace](ctx, c, namespacesPath, url.Values{"project_id": {projectId}, "cluster_id": {clusterId}})
+}
@@ -0,0 +1,51 @@
+package sellerapi
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	productsPath         = "/products"
+	productRevisionsPath = "/product-revisions"
+)
+
+func (c *Client) ListProducts(ctx context.Context) ([]Product, error) {
+	return list[Product](ctx, c, productsPath, nil)
+}
+
+func (c *Client) CreateProduct(ctx context.Context, product ProductInput) (*Product, error) {
+	return do[Product](ctx, c, http.MethodPost, productsPath, nil, product)
+}
+
+func (c *Client) GetProduct(ctx context.Context, id string) (*Product, error) {
+	return do[Product](ctx, c, http.MethodGet, byId(productsPath, id), nil, nil)
+}
+
+func (c *Client) EditProduct(ctx context.Context, id string, product ProductInput) (*Product, error) {
+	return do[Product](ctx, c, http.MethodPatch, byId(productsPath, id), nil, product)
+}
+
+func (c *Client) DeleteProduct(ctx context.Context, id string) error {
+	return deleteById(ctx, c, productsPath, id)
+}
+
+func (c *Client) ListRevisions(ctx context.Context) ([]ProductRevision, error) {
+	return list[ProductRevision](ctx, c, productRevisionsPath, nil)
+}
+
+func (c *Client) CreateRevision(ctx context.Context, revision ProductRevision) (*ProductRevision, error) {
+	return do[ProductRevision](ctx, c, http.MethodPost, productRevisionsPath, nil, revision)
+}
+
+func (c *Client) GetRevision(ctx context.Context, id string) (*ProductRevision, error) {
+	return do[ProductRevision](ctx, c, http.MethodGet, byId(productRevisionsPath, id), nil, nil)
+}
+
+func (c *Client) EditRevision(ctx context.Context, id string, revision ProductRevision) (*ProductRevision, error) {
+	return do[ProductRevision](ctx, c, http.MethodPatch, byId(productRevisionsPath, id), nil, revision)
+}
+
+func (c *Client) DeleteRevision(ctx context.Context, id string) error {
+	return deleteById(ctx, c, productRevisionsPath, id)
+}
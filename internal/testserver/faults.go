@@ -0,0 +1,95 @@
+package testserver
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Fault replaces the response to matching requests, e.g. to test retries, timeouts or error handling
+type Fault struct {
+	// Method matches any method if empty
+	Method string
+	// Path below BasePath, e.g. "/products". A trailing "*" matches every path with that prefix, empty matches any.
+	Path string
+	// Times is how many requests the fault applies to, it applies to all if zero
+	Times int
+
+	// Delay is waited before responding, or before passing the request on if StatusCode is zero and Drop is false
+	Delay time.Duration
+	// Drop closes the connection without a response, which the client sees as a network error
+	Drop bool
+	// StatusCode, Header and Body are returned instead of the real response
+	StatusCode int
+	Header     http.Header
+	Body       string
+
+	hits int
+}
+
+// InjectFault adds a fault. Faults are matched in the order they were injected.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// matchFault expects s.mu to be held
+func (s *Server) matchFault(method string, path string) *Fault {
+	for _, f := range s.faults {
+		if f.Times != 0 && f.hits >= f.Times {
+			continue
+		}
+		if f.Method != "" && f.Method != method {
+			continue
+		}
+		if prefix, ok := strings.CutSuffix(f.Path, "*"); ok {
+			if !strings.HasPrefix(path, prefix) {
+				continue
+			}
+		} else if f.Path != "" && f.Path != path {
+			continue
+		}
+
+		f.hits++
+		return f
+	}
+	return nil
+}
+
+// apply returns false if the request should still be served normally
+func (f *Fault) apply(w http.ResponseWriter) bool {
+	time.Sleep(f.Delay)
+
+	if f.Drop {
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				_ = conn.Close()
+				return true
+			}
+		}
+		// Not hijackable, at least don't send a valid response
+		panic(http.ErrAbortHandler)
+	}
+	if f.StatusCode == 0 {
+		return false
+	}
+
+	for name, values := range f.Header {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+	w.WriteHeader(f.StatusCode)
+	_, _ = w.Write([]byte(f.Body))
+	return true
+}
@@ -0,0 +1,300 @@
+package testserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// route expects s.mu to be held
+func (s *Server) route(w http.ResponseWriter, r *http.Request, path string, body []byte) {
+	collection, id, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+
+	switch {
+	case path == "/whoami" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.opts.WhoAmI)
+	case path == "/profiles/profile" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.opts.Profile)
+	case path == "/logout" && r.Method == http.MethodGet:
+		token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		delete(s.tokens, token)
+		w.WriteHeader(http.StatusOK)
+
+	case collection == Clusters && id == "" && r.Method == http.MethodGet:
+		s.listFiltered(w, r, collection, "project_id")
+	case collection == Namespaces && id == "" && r.Method == http.MethodGet:
+		s.listFiltered(w, r, collection, "project_id", "cluster_id")
+	case collection == SalesHistory && id == "" && r.Method == http.MethodGet:
+		s.listFiltered(w, r, collection)
+	case (collection == Projects || collection == Categories) && r.Method == http.MethodGet:
+		s.read(w, collection, id)
+
+	case collection == Products || collection == ProductRevisions || collection == Applications:
+		s.crud(w, r.Method, collection, id, body)
+
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s is not implemented by the test server", r.Method, path))
+	}
+}
+
+func (s *Server) crud(w http.ResponseWriter, method string, collection string, id string, body []byte) {
+	switch {
+	case method == http.MethodGet:
+		if collection == Applications && id != "" {
+			s.pollApplication(id)
+		}
+		s.read(w, collection, id)
+	case method == http.MethodPost && id == "":
+		s.create(w, collection, body)
+	case method == http.MethodPatch && id != "" && collection != Applications:
+		s.update(w, collection, id, body)
+	case method == http.MethodDelete && id != "":
+		s.delete(w, collection, id)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s is not supported on /%s", method, strings.TrimSuffix(collection+"/"+id, "/")))
+	}
+}
+
+// read returns the whole collection if id is empty
+func (s *Server) read(w http.ResponseWriter, collection string, id string) {
+	if id == "" {
+		s.listFiltered(w, nil, collection)
+		return
+	}
+
+	object, _ := s.find(collection, id)
+	if object == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s not found", collection, id))
+		return
+	}
+	writeJSON(w, http.StatusOK, object)
+}
+
+// listFiltered only returns the objects whose params match the required query parameters of the same name
+func (s *Server) listFiltered(w http.ResponseWriter, r *http.Request, collection string, params ...string) {
+	filters := map[string]string{}
+	for _, param := range params {
+		value := r.URL.Query().Get(param)
+		if value == "" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("query parameter %s is required", param))
+			return
+		}
+		filters[param] = value
+	}
+
+	objects := []Object{}
+	for _, object := range s.collections[collection] {
+		matching := true
+		for param, value := range filters {
+			matching = matching && object[param] == value
+		}
+		if matching {
+			objects = append(objects, object)
+		}
+	}
+	writeJSON(w, http.StatusOK, objects)
+}
+
+func (s *Server) create(w http.ResponseWriter, collection string, body []byte) {
+	var object Object
+	if err := json.Unmarshal(body, &object); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid body: %v", err))
+		return
+	}
+
+	var status int
+	var err error
+	switch collection {
+	case Products:
+		status, err = s.newProduct(object)
+	case ProductRevisions:
+		status, err = s.newProductRevision(object)
+	case Applications:
+		status, err = s.newApplication(object)
+	}
+	if err != nil {
+		writeError(w, status, err.Error())
+		return
+	}
+
+	s.collections[collection] = append(s.collections[collection], object)
+	writeJSON(w, status, object)
+}
+
+func (s *Server) update(w http.ResponseWriter, collection string, id string, body []byte) {
+	object, _ := s.find(collection, id)
+	if object == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s not found", collection, id))
+		return
+	}
+
+	var changes Object
+	if err := json.Unmarshal(body, &changes); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid body: %v", err))
+		return
+	}
+	// Read-only attributes can't be changed
+	for _, readOnly := range []string{"id", "created_at", "seller", "number", "weight"} {
+		delete(changes, readOnly)
+	}
+	if collection == ProductRevisions {
+		// Only the marketplace's review approves or rejects a revision, see Review
+		if state := changes["state"]; state == "approved" || state == "rejected" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("state can't be set to %v by the seller", state))
+			return
+		}
+		documentsInfo(changes)
+	}
+	for key, value := range changes {
+		object[key] = value
+	}
+	writeJSON(w, http.StatusCreated, object)
+}
+
+func (s *Server) delete(w http.ResponseWriter, collection string, id string) {
+	_, i := s.find(collection, id)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s not found", collection, id))
+		return
+	}
+
+	s.collections[collection] = append(s.collections[collection][:i], s.collections[collection][i+1:]...)
+	if collection == Products {
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// find returns the object and its index, or nil and -1
+func (s *Server) find(collection string, id string) (Object, int) {
+	for i, object := range s.collections[collection] {
+		if object["id"] == id {
+			return object, i
+		}
+	}
+	return nil, -1
+}
+
+func (s *Server) newProduct(product Object) (int, error) {
+	for _, required := range []string{"name", "type", "license_type"} {
+		if product[required] == nil || product[required] == "" {
+			return http.StatusBadRequest, fmt.Errorf("%s is required", required)
+		}
+	}
+	// The marketplace answers a reused name with a 500 rather than a 409, see resource_product
+	for _, existing := range s.collections[Products] {
+		if existing["name"] == product["name"] {
+			return http.StatusInternalServerError, fmt.Errorf("a product named %v already exists", product["name"])
+		}
+	}
+
+	product["id"] = NewNanoID()
+	product["created_at"] = time.Now().UTC().Format(time.RFC3339)
+	product["seller"] = s.seller()
+	product["weight"] = 0
+	if product["state"] == nil {
+		product["state"] = "de-published"
+	}
+	return http.StatusCreated, nil
+}
+
+func (s *Server) newProductRevision(revision Object) (int, error) {
+	productId, _ := revision["product_id"].(string)
+	if product, _ := s.find(Products, productId); product == nil {
+		return http.StatusBadRequest, fmt.Errorf("product %q doesn't exist", productId)
+	}
+
+	number := 1
+	for _, existing := range s.collections[ProductRevisions] {
+		if existing["product_id"] == productId {
+			number++
+		}
+	}
+
+	revision["id"] = NewNanoID()
+	revision["number"] = number
+	if revision["state"] == nil || revision["state"] == "" {
+		revision["state"] = "draft"
+	}
+	documentsInfo(revision)
+	return http.StatusCreated, nil
+}
+
+// documentsInfo replaces the uploaded contractual_documents with their contractual_documents_info, like the
+// marketplace, which never returns the uploaded content
+func documentsInfo(revision Object) {
+	documents, ok := revision["contractual_documents"].([]any)
+	if !ok {
+		return
+	}
+
+	info := []any{}
+	for _, document := range documents {
+		document, _ := document.(map[string]any)
+		if deleted, _ := document["is_deleted"].(bool); deleted {
+			continue
+		}
+		info = append(info, Object{"file_name": document["file_name"], "url": fmt.Sprintf("https://files.example.com/%v", document["file_name"])})
+	}
+	delete(revision, "contractual_documents")
+	revision["contractual_documents_info"] = info
+}
+
+func (s *Server) newApplication(application Object) (int, error) {
+	for _, required := range []string{"product_revision_id", "project_id", "cluster_id", "namespace"} {
+		if application[required] == nil || application[required] == "" {
+			return http.StatusBadRequest, fmt.Errorf("%s is required", required)
+		}
+	}
+
+	revisionId, _ := application["product_revision_id"].(string)
+	revision, _ := s.find(ProductRevisions, revisionId)
+	if revision == nil {
+		return http.StatusBadRequest, fmt.Errorf("product revision %q doesn't exist", revisionId)
+	}
+	product, _ := s.find(Products, fmt.Sprint(revision["product_id"]))
+
+	// The configuration is sent as application_configuration, but returned as configuration
+	if configuration, ok := application["application_configuration"]; ok {
+		application["configuration"] = configuration
+		delete(application, "application_configuration")
+	}
+	if application["release_name"] == nil || application["release_name"] == "" {
+		application["release_name"] = fmt.Sprintf("app-%x", time.Now().UnixNano())
+	}
+
+	application["id"] = NewNanoID()
+	application["state"] = "pending"
+	application["username"] = s.opts.Username
+	application["created_at"] = time.Now().UTC().Format(time.RFC3339)
+	application["product_revision"] = revision
+	application["product"] = product
+	application["seller"] = s.seller()
+	return http.StatusCreated, nil
+}
+
+// pollApplication finishes the deployment once it was polled PendingPolls times
+func (s *Server) pollApplication(id string) {
+	application, _ := s.find(Applications, id)
+	if application == nil || application["state"] != "pending" {
+		return
+	}
+
+	s.polls[id]++
+	if s.polls[id] <= s.opts.PendingPolls {
+		return
+	}
+	if s.opts.FailDeployments {
+		application["state"] = "error"
+		application["error"] = "helm install failed: injected by the test server"
+		return
+	}
+	application["state"] = "ready"
+}
+
+func (s *Server) seller() Object {
+	return Object{"id": s.opts.Profile["id"], "name": s.opts.Profile["name"], "state": "active"}
+}
@@ -0,0 +1,304 @@
+// Package testserver is an in-memory implementation of the seller API in openapi.yml, so the provider can be tested
+// without talking to the live marketplace.
+package testserver
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"terraform-provider-otc-marketplace/internal/util"
+	"time"
+)
+
+// BasePath is where the seller API is served, like on the live marketplace
+const BasePath = "/api/v1/seller"
+
+// Collections that can be seeded and inspected, named after their path in openapi.yml
+const (
+	Projects         = "projects"
+	Clusters         = "clusters"
+	Namespaces       = "namespaces"
+	Categories       = "categories"
+	SalesHistory     = "sales-history"
+	Products         = "products"
+	ProductRevisions = "product-revisions"
+	Applications     = "applications"
+)
+
+// Object is a JSON object as sent and returned by the seller API
+type Object = map[string]any
+
+// Options configures the Server. The zero value accepts any credentials and deploys applications immediately.
+type Options struct {
+	// DomainName, Username, UserId and Password are checked by `/login` if set
+	DomainName string
+	Username   string
+	UserId     string
+	Password   string
+	// TotpSecret makes `/login` require the current passcode of this base32 seed
+	TotpSecret string
+	// TokenLifetime is the `exp` of the issued JWTs, 24 hours if zero
+	TokenLifetime time.Duration
+	// PendingPolls is how many times an application is returned as `pending` before it becomes ready
+	PendingPolls int
+	// FailDeployments makes every application end up in `error` instead of `ready`
+	FailDeployments bool
+	// WhoAmI and Profile are returned by `/whoami` and `/profiles/profile`
+	WhoAmI  Object
+	Profile Object
+}
+
+// Request is a request the Server received, e.g. to assert what the provider sent
+type Request struct {
+	Method string
+	// Path below BasePath
+	Path  string
+	Query string
+	Body  []byte
+}
+
+// Server is an httptest.Server serving the seller API under BasePath. Safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	opts Options
+
+	mu          sync.Mutex
+	collections map[string][]Object
+	tokens      map[string]time.Time
+	polls       map[string]int
+	faults      []*Fault
+	requests    []Request
+}
+
+// New starts a Server, it has to be closed with Close
+func New(opts Options) *Server {
+	if opts.TokenLifetime == 0 {
+		opts.TokenLifetime = 24 * time.Hour
+	}
+	if opts.WhoAmI == nil {
+		opts.WhoAmI = Object{"domain_name": opts.DomainName, "username": opts.Username, "last_project_id": "", "llm_hub": false}
+	}
+	if opts.Profile == nil {
+		opts.Profile = Object{"id": NewNanoID(), "name": opts.Username, "status": "active"}
+	}
+
+	s := &Server{
+		opts:        opts,
+		collections: map[string][]Object{},
+		tokens:      map[string]time.Time{},
+		polls:       map[string]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// BaseURL is the endpoint to configure the provider or util.ClientOptions with
+func (s *Server) BaseURL() string {
+	return s.URL + BasePath
+}
+
+// Seed adds objects to a collection. Objects without an id get a NanoID.
+func (s *Server) Seed(collection string, objects ...Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, object := range objects {
+		object = copyObject(object)
+		// Namespaces and sales don't have an id of their own
+		if _, ok := object["id"]; !ok && collection != Namespaces && collection != SalesHistory {
+			object["id"] = NewNanoID()
+		}
+		s.collections[collection] = append(s.collections[collection], object)
+	}
+}
+
+// Get returns a copy of an object as currently stored
+func (s *Server) Get(collection string, id string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, _ := s.find(collection, id)
+	if object == nil {
+		return nil, false
+	}
+	return copyObject(object), true
+}
+
+// List returns a copy of all objects of a collection
+func (s *Server) List(collection string) []Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	objects := make([]Object, 0, len(s.collections[collection]))
+	for _, object := range s.collections[collection] {
+		objects = append(objects, copyObject(object))
+	}
+	return objects
+}
+
+// Requests returns every request received so far, in order
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// ExpireTokens revokes all issued tokens, so the next request is answered with a 401
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = map[string]time.Time{}
+}
+
+// Review approves or rejects a product revision like the marketplace's review, the adminSuggestion is only kept if it's
+// rejected. It returns false if the revision doesn't exist.
+func (s *Server) Review(revisionId string, approve bool, adminSuggestion string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revision, _ := s.find(ProductRevisions, revisionId)
+	if revision == nil {
+		return false
+	}
+	if approve {
+		revision["state"] = "approved"
+		delete(revision, "admin_suggestion")
+		return true
+	}
+	revision["state"] = "rejected"
+	revision["admin_suggestion"] = adminSuggestion
+	return true
+}
+
+// NewNanoID returns a random id in the format the marketplace uses
+func NewNanoID() string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-"
+	b := make([]byte, 21)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	for i := range b {
+		b[i] = alphabet[int(b[i])%len(alphabet)]
+	}
+	return string(b)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	path := strings.TrimPrefix(r.URL.Path, BasePath)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: path, Query: r.URL.RawQuery, Body: body})
+	fault := s.matchFault(r.Method, path)
+	s.mu.Unlock()
+
+	if fault != nil && fault.apply(w) {
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, BasePath+"/") {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if path == "/login" && r.Method == http.MethodPost {
+		s.login(w, body)
+		return
+	}
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.route(w, r, path, body)
+}
+
+func (s *Server) login(w http.ResponseWriter, body []byte) {
+	var payload struct {
+		DomainName string `json:"domain_name"`
+		Username   string `json:"username"`
+		UserId     string `json:"user_id"`
+		Password   string `json:"password"`
+		Passcode   string `json:"passcode"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid login body: %v", err))
+		return
+	}
+
+	valid := matches(s.opts.DomainName, payload.DomainName) && matches(s.opts.Password, payload.Password)
+	if s.opts.TotpSecret != "" {
+		passcode, err := util.GenerateTOTP(s.opts.TotpSecret, time.Now())
+		valid = valid && err == nil && payload.Passcode == passcode && matches(s.opts.UserId, payload.UserId)
+	} else {
+		valid = valid && (matches(s.opts.Username, payload.Username) || (payload.UserId != "" && matches(s.opts.UserId, payload.UserId)))
+	}
+	if !valid {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	expiresAt := time.Now().Add(s.opts.TokenLifetime)
+	token := newJWT(expiresAt)
+
+	s.mu.Lock()
+	s.tokens[token] = expiresAt
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, Object{"token": token})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.tokens[token]
+	return ok && time.Now().Before(expiresAt)
+}
+
+// matches treats an unset expected value as a wildcard
+func matches(expected string, actual string) bool {
+	return expected == "" || expected == actual
+}
+
+// newJWT returns an unsigned JWT, the provider only reads its `exp` claim
+func newJWT(expiresAt time.Time) string {
+	encode := func(v any) string {
+		b, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	return fmt.Sprintf("%s.%s.%s", encode(Object{"alg": "none", "typ": "JWT"}), encode(Object{"exp": expiresAt.Unix(), "jti": NewNanoID()}), "testserver")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError answers in the `{code, error}` format of the BadRequest/NotFound responses in openapi.yml
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, Object{"code": status, "error": message})
+}
+
+func copyObject(object Object) Object {
+	b, _ := json.Marshal(object)
+	var copied Object
+	_ = json.Unmarshal(b, &copied)
+	return copied
+}
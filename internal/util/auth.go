@@ -0,0 +1,178 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// The JWT returned by `/login` is valid for 24 hours, this is only used if the token has no readable `exp` claim
+	defaultTokenLifetime = 24 * time.Hour
+	// Log in again this long before the token actually expires, so requests in flight don't race the expiry
+	tokenRefreshMargin = 5 * time.Minute
+)
+
+// LoginPayloadFunc builds the body sent to `/login`. It's called for every (re-)login, so one-time passcodes can be
+// regenerated when the token needs to be refreshed.
+type LoginPayloadFunc func() (map[string]string, error)
+
+// Login posts to `/login` and stores the returned token. Safe for concurrent use.
+func (c *MarketplaceAPIClient) Login(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.login(ctx)
+}
+
+// login expects c.mu to be held
+func (c *MarketplaceAPIClient) login(ctx context.Context) error {
+	if c.LoginPayload == nil {
+		return errors.New("no login credentials configured")
+	}
+
+	payload, err := c.LoginPayload()
+	if err != nil {
+		return err
+	}
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/login", c.BaseURL)
+	// Logging in has no side effects, so it's safe to retry even though it's a POST
+	resHttp, err := c.doWithRetries(ctx, true, func() (*http.Request, error) {
+		reqHttp, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonPayload))
+		if err != nil {
+			return nil, err
+		}
+		reqHttp.Header.Set("Content-Type", "application/json")
+		return reqHttp, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resHttp.Body.Close()
+
+	if resHttp.StatusCode != http.StatusOK {
+		// Don't keep the body around, it might echo the credentials
+		body, _ := io.ReadAll(resHttp.Body)
+		apiErr := newAPIError(resHttp.StatusCode, http.MethodPost, "/login", body)
+		apiErr.Body = nil
+		return apiErr
+	}
+
+	var response struct {
+		Token string `json:"token"`
+	}
+	if err = json.NewDecoder(resHttp.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	if response.Token == "" {
+		return errors.New("token is missing from the API response")
+	}
+
+	expiresAt, err := jwtExpiry(response.Token)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("couldn't read expiry of the marketplace token, assuming %s: %v", defaultTokenLifetime, err))
+		expiresAt = time.Now().Add(defaultTokenLifetime)
+	}
+
+	c.token = response.Token
+	c.expiresAt = expiresAt
+	tflog.Debug(ctx, fmt.Sprintf("logged in to the marketplace, token expires at %s", expiresAt.Format(time.RFC3339)))
+	return nil
+}
+
+// Token returns a token that's valid for at least tokenRefreshMargin, logging in again if needed. If logging in
+// again fails, the current token is returned for as long as it's valid.
+func (c *MarketplaceAPIClient) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// A token set with UseToken can't be refreshed, so it's used until it's rejected
+	if c.token != "" && (c.LoginPayload == nil || time.Until(c.expiresAt) > tokenRefreshMargin) {
+		return c.token, nil
+	}
+
+	if c.token != "" {
+		tflog.Info(ctx, "marketplace token is about to expire, logging in again")
+	}
+	if err := c.login(ctx); err != nil {
+		// A static passcode can't log in again, the current token is still good until it actually expires
+		if c.token != "" && time.Now().Before(c.expiresAt) {
+			tflog.Warn(ctx, fmt.Sprintf("couldn't refresh the marketplace token, using it until it expires at %s: %v", c.expiresAt.Format(time.RFC3339), err))
+			return c.token, nil
+		}
+		return "", err
+	}
+	return c.token, nil
+}
+
+// UseToken sets a token obtained by another client, e.g. to log it out
+func (c *MarketplaceAPIClient) UseToken(token string, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.token = token
+	c.expiresAt = expiresAt
+}
+
+// TokenExpiry returns when the current token expires. The zero time is returned if there's no token yet.
+func (c *MarketplaceAPIClient) TokenExpiry() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.expiresAt
+}
+
+// invalidateToken drops rejectedToken so the next call to Token logs in again. Tokens that were already replaced by a
+// concurrent caller are left alone, so a burst of 401s only causes a single re-login.
+func (c *MarketplaceAPIClient) invalidateToken(rejectedToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token == rejectedToken {
+		c.token = ""
+		c.expiresAt = time.Time{}
+	}
+}
+
+// jwtExpiry reads the `exp` claim of a JWT without verifying its signature - the backend is the one doing that.
+func jwtExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("token is not a JWT, expected 3 parts but got %d", len(parts))
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("couldn't decode JWT claims: %w", err)
+	}
+
+	var claims struct {
+		Exp *json.Number `json:"exp"`
+	}
+	if err = json.Unmarshal(claimsJSON, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("couldn't parse JWT claims: %w", err)
+	}
+	if claims.Exp == nil {
+		return time.Time{}, errors.New("JWT has no exp claim")
+	}
+
+	exp, err := claims.Exp.Float64()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("JWT exp claim is not a number: %w", err)
+	}
+	return time.Unix(int64(exp), 0), nil
+}
@@ -0,0 +1,104 @@
+package util
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testJWT returns an unsigned JWT expiring at exp, jwtExpiry doesn't check the signature
+func testJWT(t *testing.T, exp time.Time) string {
+	t.Helper()
+
+	claims, err := json.Marshal(map[string]int64{"exp": exp.Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	encode := base64.RawURLEncoding.EncodeToString
+	return fmt.Sprintf("%s.%s.%s", encode([]byte(`{"alg":"none"}`)), encode(claims), encode([]byte("signature")))
+}
+
+// newLoginServer serves `/login`, the first login returns a token expiring in lifetime and later ones fail
+func newLoginServer(t *testing.T, lifetime time.Duration) (*MarketplaceAPIClient, *atomic.Int32) {
+	t.Helper()
+
+	var logins atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/login" {
+			http.NotFound(w, r)
+			return
+		}
+		if logins.Add(1) > 1 {
+			// The passcode was already used
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": testJWT(t, time.Now().Add(lifetime))})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewMarketplaceAPIClient(func() (map[string]string, error) {
+		return map[string]string{"passcode": "123456"}, nil
+	}, ClientOptions{BaseURL: server.URL, MaxRetries: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, &logins
+}
+
+func TestTokenKeepsValidTokenWhenRefreshFails(t *testing.T) {
+	ctx := context.Background()
+	// Within tokenRefreshMargin, so the second call tries to log in again
+	client, logins := newLoginServer(t, 2*time.Minute)
+
+	first, err := client.Token(ctx)
+	if err != nil {
+		t.Fatalf("first login failed: %v", err)
+	}
+	second, err := client.Token(ctx)
+	if err != nil {
+		t.Fatalf("expected the current token after a failed refresh, got error: %v", err)
+	}
+	if second != first {
+		t.Errorf("expected the current token after a failed refresh")
+	}
+	if got := logins.Load(); got != 2 {
+		t.Errorf("expected a refresh attempt, got %d logins", got)
+	}
+}
+
+func TestTokenFailsWhenRefreshFailsAfterExpiry(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newLoginServer(t, time.Hour)
+
+	token, err := client.Token(ctx)
+	if err != nil {
+		t.Fatalf("first login failed: %v", err)
+	}
+	client.UseToken(token, time.Now().Add(-time.Second))
+
+	if _, err := client.Token(ctx); err == nil {
+		t.Errorf("expected an error for an expired token that can't be refreshed")
+	}
+}
+
+func TestTokenFailsWhenRejectedTokenCantBeRefreshed(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newLoginServer(t, time.Hour)
+
+	token, err := client.Token(ctx)
+	if err != nil {
+		t.Fatalf("first login failed: %v", err)
+	}
+	client.invalidateToken(token)
+
+	if _, err := client.Token(ctx); err == nil {
+		t.Errorf("expected an error once the backend rejected the token")
+	}
+}
@@ -11,202 +11,107 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"io"
-	"log"
 	"net/http"
-	"strconv"
 	"strings"
 )
 
-func NewMarketplaceAPIClient() MarketplaceAPIClient {
-	return MarketplaceAPIClient{
-		BaseURL: "https://marketplace.otc.t-systems.com/api/v1/seller",
-		Token:   "",
-	}
-}
-
-// TODO - remove when no longer needed
-// This only exists because the backend, when returning PRs, sets the `default_value` attribute to a string
-// if the `input_type` is text or selection, but a bool if it's set to switch, which - naturally, causes absolute
-// havoc upstream.
-func MakePRMarketplaceRequest[T any](ctx context.Context, method string, path string, body io.Reader, marketplaceClient *MarketplaceAPIClient) (*T, error) {
-	if body != nil {
-		var resultMap map[string]interface{}
-		bodyBytes, err := io.ReadAll(body)
-		if err != nil {
-			return nil, errors.Join(err, errors.New("couldn't read body"))
-		}
-
-		if len(bodyBytes) > 0 {
-			err = json.Unmarshal(bodyBytes, &resultMap)
-			if err != nil {
-				return nil, fmt.Errorf("failed to decode response: %w", err)
-			}
-		}
-
-		configArray, ok := resultMap["configuration"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("configArray is not an array. configArray: %+v", configArray)
-		}
-
-		for i, config := range configArray {
-			configMap, ok := config.(map[string]interface{})
-			if !ok {
-				return nil, fmt.Errorf("configArray[%d] is not a map[string]interface{} - config: %+v", i, config)
-			}
-
-			inputType, ok := configMap["input_type"].(string)
-			if !ok {
-				return nil, fmt.Errorf("input_type is not a string. input_type: %+v", configMap["input_type"])
-			}
-
-			if inputType == "switch" {
-				defaultValue, ok := configMap["default_value"]
-				if !ok {
-					continue // Could *technically* be missing and still pass the openapi spec
-				}
-
-				defaultValueBool, ok := defaultValue.(bool) // Unlikely
-				if !ok {
-					tflog.Warn(ctx, "Forcing conversions of default_value to a bool before sending to backend. See TODO.md")
-					defaultValueStrNoQuotes := strings.Replace(strings.Replace(fmt.Sprintf("%v", defaultValue), "\"", "", -1), "'", "", -1)
-					defaultValueBool, err = strconv.ParseBool(defaultValueStrNoQuotes)
-					if err != nil {
-						return nil, fmt.Errorf("couldn't convert defaultValue to a bool. defaultValue: %+v, defaultValueStrNoQuotes: %+v, err: %+v", defaultValue, defaultValueStrNoQuotes, err)
-					}
-				}
-
-				configMap["default_value"] = defaultValueBool
-				configArray[i] = configMap
-			}
-		}
-
-		resultMap["configuration"] = configArray
-
-		bodyStr, err := json.Marshal(resultMap)
-		if err != nil {
-			return nil, fmt.Errorf("couldn't marshal resultMap to json. resultMap: %+v, err: %+v", resultMap, err)
-		}
-
-		body = bytes.NewReader(bodyStr)
-	}
-
-	url := fmt.Sprintf("%s%s", marketplaceClient.BaseURL, path)
-	reqHttp, err := http.NewRequestWithContext(ctx, method, url, body)
+func NewMarketplaceAPIClient(loginPayload LoginPayloadFunc, opts ClientOptions) (*MarketplaceAPIClient, error) {
+	baseURL, err := normalizeBaseURL(opts.BaseURL)
 	if err != nil {
 		return nil, err
 	}
-	reqHttp.Header.Set("Authorization", fmt.Sprintf("Bearer %s", marketplaceClient.Token))
-	reqHttp.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resHttp, err := client.Do(reqHttp)
+	httpClient, err := newHTTPClient(opts)
 	if err != nil {
 		return nil, err
 	}
 
-	// 2xx to 300
-	if !(resHttp.StatusCode >= http.StatusOK && resHttp.StatusCode < http.StatusMultipleChoices) {
-		return nil, fmt.Errorf("unexpected status code: %d", resHttp.StatusCode)
-	}
+	return &MarketplaceAPIClient{
+		BaseURL:      baseURL,
+		LoginPayload: loginPayload,
+		HTTPClient:   httpClient,
+		MaxRetries:   max(opts.MaxRetries, 0),
+	}, nil
+}
 
-	var resultMap map[string]interface{}
-	bodyBytes, err := io.ReadAll(resHttp.Body)
+func sendMarketplaceRequest(ctx context.Context, method string, url string, body []byte, marketplaceClient *MarketplaceAPIClient) (*http.Response, string, error) {
+	token, err := marketplaceClient.Token(ctx)
 	if err != nil {
-		log.Fatal(err)
+		return nil, "", fmt.Errorf("couldn't authenticate: %w", err)
 	}
-	tflog.Debug(ctx, fmt.Sprintf("method: %s, url: %s, body: %s", method, url, string(bodyBytes)))
 
-	// convert switch bool default_value to string!
-	if len(bodyBytes) > 0 {
-		err = json.Unmarshal(bodyBytes, &resultMap)
+	resHttp, err := marketplaceClient.doWithRetries(ctx, isIdempotent(method), func() (*http.Request, error) {
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(body)
+		}
+		reqHttp, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 		if err != nil {
-			return nil, fmt.Errorf("failed to decode response: %w", err)
+			return nil, err
 		}
+		reqHttp.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		reqHttp.Header.Set("Content-Type", "application/json")
+		return reqHttp, nil
+	})
+	if err != nil {
+		return nil, "", err
 	}
+	return resHttp, token, nil
+}
 
-	configArray, ok := resultMap["configuration"].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("configArray is not an array. configArray: %+v", configArray)
+// doMarketplaceRequest sends the request and returns the body of a 2xx response. If the backend rejects the token
+// (e.g. it was revoked or expired early), the request is retried once after logging in again.
+func doMarketplaceRequest(ctx context.Context, method string, path string, body []byte, marketplaceClient *MarketplaceAPIClient) ([]byte, error) {
+	url := fmt.Sprintf("%s%s", marketplaceClient.BaseURL, path)
+	if body != nil {
+		tflog.Debug(ctx, fmt.Sprintf("method: %s, url: %s, request body: %s", method, url, RedactBody(ctx, body)))
+	}
+	resHttp, token, err := sendMarketplaceRequest(ctx, method, url, body, marketplaceClient)
+	if err != nil {
+		return nil, err
 	}
 
-	for i, config := range configArray {
-		configMap, ok := config.(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("configArray[%d] is not a map[string]interface{} - config: %+v", i, config)
-		}
-
-		inputType, ok := configMap["input_type"].(string)
-		if !ok {
-			return nil, fmt.Errorf("input_type is not a string. input_type: %+v", configMap["input_type"])
-		}
-
-		if inputType == "switch" {
-			defaultValue, ok := configMap["default_value"]
-			if !ok {
-				continue // Could *technically* be missing and still pass the openapi spec
-			}
-
-			defaultValueStr, ok := defaultValue.(string) // Unlikely
-			if !ok {
-				tflog.Warn(ctx, "Forcing conversions of default_value to a string before persisting. See TODO.md")
-				defaultValueStr = fmt.Sprintf("%v", defaultValue)
-			}
+	if resHttp.StatusCode == http.StatusUnauthorized {
+		_ = resHttp.Body.Close()
+		tflog.Info(ctx, fmt.Sprintf("method: %s, url: %s was unauthorized, logging in again and retrying once", method, url))
+		marketplaceClient.invalidateToken(token)
 
-			configMap["default_value"] = defaultValueStr
-			configArray[i] = configMap
+		resHttp, _, err = sendMarketplaceRequest(ctx, method, url, body, marketplaceClient)
+		if err != nil {
+			return nil, err
 		}
 	}
+	defer resHttp.Body.Close()
 
-	resultMap["configuration"] = configArray
-
-	bodyBytes, err = json.Marshal(resultMap)
+	bodyBytes, err := io.ReadAll(resHttp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't marshal resultMap to json. resultMap: %+v, err: %+v", resultMap, err)
+		return nil, errors.Join(err, errors.New("couldn't read response body"))
 	}
+	tflog.Debug(ctx, fmt.Sprintf("method: %s, url: %s, status: %d, body: %s", method, url, resHttp.StatusCode, RedactBody(ctx, bodyBytes)))
 
-	var result T
-	if len(bodyBytes) > 0 {
-		err = json.NewDecoder(bytes.NewReader(bodyBytes)).Decode(&result)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode response: %w", err)
-		}
-	} else {
-		tflog.Debug(ctx, "skipping body.decode() since len(bodyBytes) is not larger than 0")
+	// 2xx to 300
+	if !(resHttp.StatusCode >= http.StatusOK && resHttp.StatusCode < http.StatusMultipleChoices) {
+		// The marketplace may echo the request in its error
+		return nil, newAPIError(resHttp.StatusCode, method, path, []byte(RedactBody(ctx, bodyBytes)))
 	}
 
-	err = resHttp.Body.Close()
-	if err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return bodyBytes, nil
 }
 
 func MakeMarketplaceRequest[T any](ctx context.Context, method string, path string, body io.Reader, marketplaceClient *MarketplaceAPIClient) (*T, error) {
-	url := fmt.Sprintf("%s%s", marketplaceClient.BaseURL, path)
-	reqHttp, err := http.NewRequestWithContext(ctx, method, url, body)
-	if err != nil {
-		return nil, err
+	var reqBodyBytes []byte
+	if body != nil {
+		var err error
+		reqBodyBytes, err = io.ReadAll(body)
+		if err != nil {
+			return nil, errors.Join(err, errors.New("couldn't read body"))
+		}
 	}
-	reqHttp.Header.Set("Authorization", fmt.Sprintf("Bearer %s", marketplaceClient.Token))
-	reqHttp.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	resHttp, err := client.Do(reqHttp)
+	bodyBytes, err := doMarketplaceRequest(ctx, method, path, reqBodyBytes, marketplaceClient)
 	if err != nil {
 		return nil, err
 	}
 
-	// 2xx to 300
-	if !(resHttp.StatusCode >= http.StatusOK && resHttp.StatusCode < http.StatusMultipleChoices) {
-		return nil, fmt.Errorf("unexpected status code: %d", resHttp.StatusCode)
-	}
-
-	bodyBytes, err := io.ReadAll(resHttp.Body)
-	if err != nil {
-		log.Fatal(err)
-	}
-	tflog.Debug(ctx, fmt.Sprintf("method: %s, url: %s, body: %s", method, url, string(bodyBytes)))
-
 	var result T
 	if len(bodyBytes) > 0 {
 		err = json.NewDecoder(bytes.NewReader(bodyBytes)).Decode(&result)
@@ -217,10 +122,6 @@ func MakeMarketplaceRequest[T any](ctx context.Context, method string, path stri
 		tflog.Debug(ctx, "skipping body.decode() since len(bodyBytes) is not larger than 0")
 	}
 
-	err = resHttp.Body.Close()
-	if err != nil {
-		return nil, err
-	}
 	return &result, nil
 }
 
@@ -0,0 +1,82 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Non-JSON error bodies (e.g. HTML from a proxy) are cut off after this many bytes in the error message
+const maxErrorBodyLength = 512
+
+// APIError is returned for every non-2xx response of the marketplace. Use errors.As, or the IsNotFound/IsConflict
+// helpers, to react to specific failures.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	// Code and Message are parsed from the `{code, error}` body of the BadRequest/NotFound responses in openapi.yml
+	Code    int
+	Message string
+	// Body is the raw response body
+	Body []byte
+}
+
+// newAPIError parses the backend error body, if there's one in the documented format
+func newAPIError(statusCode int, method string, path string, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Method:     method,
+		Path:       path,
+		Body:       body,
+	}
+
+	var errorBody struct {
+		Code  int    `json:"code"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &errorBody); err == nil && errorBody.Error != "" {
+		apiErr.Code = errorBody.Code
+		apiErr.Message = errorBody.Error
+		return apiErr
+	}
+
+	message := strings.TrimSpace(string(body))
+	if len(message) > maxErrorBodyLength {
+		message = message[:maxErrorBodyLength] + "..."
+	}
+	apiErr.Message = message
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s failed with status code %d (%s)", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message == "" {
+		return msg
+	}
+	if e.Code != 0 {
+		return fmt.Sprintf("%s: %s (error code %d)", msg, e.Message, e.Code)
+	}
+	return fmt.Sprintf("%s: %s", msg, e.Message)
+}
+
+// StatusCode returns the status code of the APIError in err's chain, or 0 if there's none
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether the marketplace responded with 404
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsConflict reports whether the marketplace responded with 409
+func IsConflict(err error) bool {
+	return StatusCode(err) == http.StatusConflict
+}
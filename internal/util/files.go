@@ -0,0 +1,124 @@
+package util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// iconAspectTolerance allows icons to be a few pixels off 16:9 after scaling, e.g. the 378x212 example in openapi.yml
+const iconAspectTolerance = 0.02
+
+// File is a local file prepared for the marketplace
+type File struct {
+	Name     string
+	MimeType string
+	Content  []byte
+}
+
+// DataURI returns the file as a base64 data URI, which is how the marketplace expects icons and documents
+func (f File) DataURI() string {
+	return fmt.Sprintf("data:%s;base64,%s", f.MimeType, base64.StdEncoding.EncodeToString(f.Content))
+}
+
+// SHA256 returns the hex encoded SHA-256 of the content, used to track changes without storing the content
+func (f File) SHA256() string {
+	sum := sha256.Sum256(f.Content)
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadIcon reads an icon and checks it's an image in 16:9 format
+func LoadIcon(path string) (*File, error) {
+	file, err := loadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateIcon(file.MimeType, file.Content); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return file, nil
+}
+
+// NewIcon checks icon content that wasn't read from a file, e.g. base64 passed to a provider function
+func NewIcon(name string, content []byte) (*File, error) {
+	if len(content) == 0 {
+		return nil, errors.New("the icon is empty")
+	}
+	file := &File{Name: name, MimeType: detectMimeType(name, content), Content: content}
+	if err := ValidateIcon(file.MimeType, file.Content); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// ValidateIcon checks the icon is an image in 16:9 format, openapi.yml documents it as a "Base64 encoded image in
+// 16:9 format"
+func ValidateIcon(mimeType string, content []byte) error {
+	if !strings.HasPrefix(mimeType, "image/") {
+		return fmt.Errorf("icons have to be an image, got %s", mimeType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("couldn't read the %s image, icons have to be PNG, JPEG or GIF: %w", mimeType, err)
+	}
+	if config.Height == 0 {
+		return errors.New("the image has no height")
+	}
+	ratio := float64(config.Width) / float64(config.Height)
+	if math.Abs(ratio-16.0/9.0) > 16.0/9.0*iconAspectTolerance {
+		return fmt.Errorf("icons have to be in 16:9 format, got %dx%d", config.Width, config.Height)
+	}
+	return nil
+}
+
+// LoadDocument reads a contractual document
+func LoadDocument(path string) (*File, error) {
+	return loadFile(path)
+}
+
+func loadFile(path string) (*File, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", path)
+	}
+	// openapi.yml sets no maximum size for icons and documents, only that they're sent as base64 data URIs
+	if info.Size() == 0 {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &File{Name: filepath.Base(path), MimeType: detectMimeType(path, content), Content: content}, nil
+}
+
+// detectMimeType sniffs the content, and falls back to the extension for types that can't be sniffed, e.g. office
+// documents
+func detectMimeType(path string, content []byte) string {
+	mimeType, _, _ := mime.ParseMediaType(http.DetectContentType(content))
+	if mimeType != "application/octet-stream" && mimeType != "text/plain" && mimeType != "application/zip" {
+		return mimeType
+	}
+	if byExtension, _, err := mime.ParseMediaType(mime.TypeByExtension(filepath.Ext(path))); err == nil && byExtension != "" {
+		return byExtension
+	}
+	return mimeType
+}
@@ -0,0 +1,103 @@
+package util
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func encodePNG(t *testing.T, width int, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, width int, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestLoadIcon(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		content  []byte
+		mimeType string
+		wantErr  string
+	}{
+		{name: "16:9 PNG", fileName: "icon.png", content: encodePNG(t, 1600, 900), mimeType: "image/png"},
+		{name: "16:9 JPEG", fileName: "icon.jpg", content: encodeJPEG(t, 320, 180), mimeType: "image/jpeg"},
+		{name: "a few pixels off 16:9", fileName: "icon.png", content: encodePNG(t, 378, 212), mimeType: "image/png"},
+		{name: "square", fileName: "icon.png", content: encodePNG(t, 512, 512), wantErr: "16:9 format, got 512x512"},
+		{name: "4:3", fileName: "icon.png", content: encodePNG(t, 800, 600), wantErr: "16:9 format, got 800x600"},
+		{name: "portrait", fileName: "icon.png", content: encodePNG(t, 900, 1600), wantErr: "16:9 format, got 900x1600"},
+		{name: "not an image", fileName: "icon.txt", content: []byte("not an icon"), wantErr: "have to be an image"},
+		{name: "undecodable image", fileName: "icon.svg", content: []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`), wantErr: "couldn't read the image/svg+xml image"},
+		{name: "empty", fileName: "icon.png", content: []byte{}, wantErr: "is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.fileName)
+			if err := os.WriteFile(path, tt.content, 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			icon, err := LoadIcon(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if icon.MimeType != tt.mimeType {
+				t.Errorf("expected %s, got %s", tt.mimeType, icon.MimeType)
+			}
+		})
+	}
+}
+
+func TestNewIcon(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		wantErr string
+	}{
+		{name: "16:9", content: encodePNG(t, 320, 180)},
+		{name: "wrong ratio", content: encodePNG(t, 300, 200), wantErr: "16:9 format, got 300x200"},
+		{name: "empty", content: nil, wantErr: "the icon is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			icon, err := NewIcon("", tt.content)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.HasPrefix(icon.DataURI(), "data:image/png;base64,") {
+				t.Errorf("unexpected data URI %s", icon.DataURI())
+			}
+		})
+	}
+}
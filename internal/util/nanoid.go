@@ -0,0 +1,31 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"regexp"
+	"strings"
+)
+
+// nanoIDPattern is the `NanoID` schema from openapi.yml, used for the ids of all marketplace objects
+var nanoIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{21,}$`)
+
+func IsNanoID(s string) bool {
+	return nanoIDPattern.MatchString(s)
+}
+
+// ImportStateByNanoID sets the id of the imported resource, the rest of the state is then populated by its Read
+func ImportStateByNanoID(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := strings.TrimSpace(req.ID)
+	if !IsNanoID(id) {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected the NanoID of the object as shown in the seller dashboard (matching %s), got %q.", nanoIDPattern, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
@@ -0,0 +1,119 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strings"
+)
+
+// Redacted replaces secrets in logs and errors
+const Redacted = "***"
+
+// sensitiveKeys are JSON keys whose values are always redacted, compared case-insensitively
+var sensitiveKeys = map[string]bool{
+	"password":      true,
+	"passcode":      true,
+	"totp_secret":   true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"authorization": true,
+	"secret":        true,
+	"byol_license":  true,
+}
+
+type sensitiveValuesKey struct{}
+
+// WithSensitiveValues redacts the values from everything logged with the returned context, and from the errors of
+// requests sent with it. Used for values the marketplace has no sensitive key for, e.g. confidential configuration.
+func WithSensitiveValues(ctx context.Context, values ...string) context.Context {
+	var nonEmpty []string
+	for _, value := range values {
+		if value != "" {
+			nonEmpty = append(nonEmpty, value)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return ctx
+	}
+
+	existing, _ := ctx.Value(sensitiveValuesKey{}).([]string)
+	ctx = context.WithValue(ctx, sensitiveValuesKey{}, append(append([]string(nil), existing...), nonEmpty...))
+	return tflog.MaskMessageStrings(ctx, nonEmpty...)
+}
+
+// redactValues replaces the values registered with WithSensitiveValues in s
+func redactValues(ctx context.Context, s string) string {
+	values, _ := ctx.Value(sensitiveValuesKey{}).([]string)
+	for _, value := range values {
+		s = strings.ReplaceAll(s, value, Redacted)
+	}
+	return s
+}
+
+// RedactBody returns a JSON body for logging, with the values of sensitive keys and of confidential configuration
+// redacted. A configuration is confidential if any template in the body, e.g. the product_revision of an application,
+// marks its key as `confidential`. Bodies that aren't JSON are returned as they are.
+func RedactBody(ctx context.Context, body []byte) string {
+	var document any
+	if len(body) == 0 || json.Unmarshal(body, &document) != nil {
+		return redactValues(ctx, string(body))
+	}
+
+	confidential := map[string]bool{}
+	collectConfidentialKeys(document, confidential)
+	redactDocument(document, confidential)
+
+	var redacted strings.Builder
+	encoder := json.NewEncoder(&redacted)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(document); err != nil {
+		return Redacted
+	}
+	return redactValues(ctx, strings.TrimSuffix(redacted.String(), "\n"))
+}
+
+func collectConfidentialKeys(document any, confidential map[string]bool) {
+	switch v := document.(type) {
+	case map[string]any:
+		if key, ok := v["key"].(string); ok && v["confidential"] == true {
+			confidential[key] = true
+		}
+		for _, child := range v {
+			collectConfidentialKeys(child, confidential)
+		}
+	case []any:
+		for _, child := range v {
+			collectConfidentialKeys(child, confidential)
+		}
+	}
+}
+
+func redactDocument(document any, confidential map[string]bool) {
+	switch v := document.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if sensitiveKeys[strings.ToLower(key)] {
+				if _, ok := child.(string); ok {
+					v[key] = Redacted
+				}
+				continue
+			}
+			redactDocument(child, confidential)
+		}
+		// Configuration entries and their templates
+		if key, ok := v["key"].(string); ok && confidential[key] {
+			if _, ok := v["value"].(string); ok {
+				v["value"] = Redacted
+			}
+			if _, ok := v["default_value"].(string); ok {
+				v["default_value"] = Redacted
+			}
+		}
+	case []any:
+		for _, child := range v {
+			redactDocument(child, confidential)
+		}
+	}
+}
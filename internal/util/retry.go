@@ -0,0 +1,118 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultMaxRetries = 3
+	retryWaitMin      = 1 * time.Second
+	retryWaitMax      = 30 * time.Second
+	// Upper bound for Retry-After, so a misbehaving backend can't stall an apply indefinitely
+	retryAfterMax = 2 * time.Minute
+)
+
+// isIdempotent reports whether sending the request again can't have a different effect on the backend than sending
+// it once
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// shouldRetry decides whether a request is retried. 429 means the backend didn't process the request, so it's
+// retried for every method. Network errors and 502/503/504 are only retried if the request is safe to send again.
+func shouldRetry(ctx context.Context, res *http.Response, err error, safeToRetry bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return safeToRetry && !errors.Is(err, context.Canceled)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return safeToRetry
+	default:
+		return false
+	}
+}
+
+// retryWait returns how long to wait before the next attempt: the Retry-After of the response if there's one,
+// otherwise an exponential backoff with jitter.
+func retryWait(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			return min(wait, retryAfterMax)
+		}
+	}
+
+	backoff := retryWaitMax
+	if attempt < 16 {
+		backoff = min(retryWaitMin<<attempt, retryWaitMax)
+	}
+	// Equal jitter: wait at least half the backoff, so concurrent retries spread out without retrying immediately
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// parseRetryAfter reads both forms of the Retry-After header: delay-seconds and HTTP-date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// doWithRetries sends the request built by newRequest, retrying transient failures up to c.MaxRetries times.
+// newRequest is called for every attempt, since a request body can only be read once.
+func (c *MarketplaceAPIClient) doWithRetries(ctx context.Context, safeToRetry bool, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		reqHttp, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		resHttp, err := c.HTTPClient.Do(reqHttp)
+		if attempt >= c.MaxRetries || !shouldRetry(ctx, resHttp, err, safeToRetry) {
+			return resHttp, err
+		}
+
+		wait := retryWait(attempt, resHttp)
+		reason := fmt.Sprintf("%v", err)
+		if resHttp != nil {
+			reason = fmt.Sprintf("status code %d", resHttp.StatusCode)
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, resHttp.Body)
+			_ = resHttp.Body.Close()
+		}
+		tflog.Warn(ctx, fmt.Sprintf("method: %s, url: %s failed with %s, retrying in %s (retry %d of %d)",
+			reqHttp.Method, reqHttp.URL.Redacted(), reason, wait.Round(time.Millisecond), attempt+1, c.MaxRetries))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRetryServer answers with the given statuses in order and 200 once they're used up. Retry-After is set on every
+// failure, so the tests don't wait for the backoff.
+func newRetryServer(t *testing.T, retryAfter string, statuses ...int) (string, *atomic.Int32) {
+	t.Helper()
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempt := int(attempts.Add(1))
+		if attempt <= len(statuses) {
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(statuses[attempt-1])
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, &attempts
+}
+
+// newRetryClient returns a client with a token that's valid for an hour, so requests don't log in
+func newRetryClient(t *testing.T, baseURL string, maxRetries int) *MarketplaceAPIClient {
+	t.Helper()
+
+	client, err := NewMarketplaceAPIClient(nil, ClientOptions{BaseURL: baseURL, MaxRetries: maxRetries})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.UseToken("token", time.Now().Add(time.Hour))
+	return client
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		statuses     []int
+		maxRetries   int
+		wantAttempts int32
+		wantStatus   int
+	}{
+		{name: "POST isn't retried on 502", method: http.MethodPost, statuses: []int{502}, maxRetries: 3, wantAttempts: 1, wantStatus: 502},
+		{name: "POST isn't retried on 503", method: http.MethodPost, statuses: []int{503}, maxRetries: 3, wantAttempts: 1, wantStatus: 503},
+		{name: "POST isn't retried on 504", method: http.MethodPost, statuses: []int{504}, maxRetries: 3, wantAttempts: 1, wantStatus: 504},
+		{name: "PATCH isn't retried on 503", method: http.MethodPatch, statuses: []int{503}, maxRetries: 3, wantAttempts: 1, wantStatus: 503},
+		{name: "GET is retried on 503", method: http.MethodGet, statuses: []int{503, 502}, maxRetries: 3, wantAttempts: 3},
+		{name: "PUT is retried on 504", method: http.MethodPut, statuses: []int{504}, maxRetries: 3, wantAttempts: 2},
+		{name: "DELETE is retried on 502", method: http.MethodDelete, statuses: []int{502}, maxRetries: 3, wantAttempts: 2},
+		{name: "500 isn't retried", method: http.MethodGet, statuses: []int{500}, maxRetries: 3, wantAttempts: 1, wantStatus: 500},
+		{name: "GET is retried on 429", method: http.MethodGet, statuses: []int{429}, maxRetries: 3, wantAttempts: 2},
+		{name: "POST is retried on 429", method: http.MethodPost, statuses: []int{429, 429}, maxRetries: 3, wantAttempts: 3},
+		{name: "PATCH is retried on 429", method: http.MethodPatch, statuses: []int{429}, maxRetries: 3, wantAttempts: 2},
+		{name: "PUT is retried on 429", method: http.MethodPut, statuses: []int{429}, maxRetries: 3, wantAttempts: 2},
+		{name: "DELETE is retried on 429", method: http.MethodDelete, statuses: []int{429}, maxRetries: 3, wantAttempts: 2},
+		{name: "max retries caps the attempts", method: http.MethodGet, statuses: []int{503, 503, 503, 503}, maxRetries: 2, wantAttempts: 3, wantStatus: 503},
+		{name: "max retries caps 429", method: http.MethodPost, statuses: []int{429, 429, 429}, maxRetries: 1, wantAttempts: 2, wantStatus: 429},
+		{name: "zero max retries disables retries", method: http.MethodGet, statuses: []int{429}, maxRetries: 0, wantAttempts: 1, wantStatus: 429},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL, attempts := newRetryServer(t, "0", tt.statuses...)
+			client := newRetryClient(t, baseURL, tt.maxRetries)
+
+			_, err := MakeMarketplaceRequest[map[string]any](context.Background(), tt.method, "/test", nil, client)
+
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.wantAttempts, got)
+			}
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus {
+				t.Errorf("expected an APIError with status %d, got %v", tt.wantStatus, err)
+			}
+		})
+	}
+}
+
+func TestRetryAfterIsHonored(t *testing.T) {
+	baseURL, attempts := newRetryServer(t, "1", http.StatusTooManyRequests)
+	client := newRetryClient(t, baseURL, 3)
+
+	start := time.Now()
+	if _, err := MakeMarketplaceRequest[map[string]any](context.Background(), http.MethodPost, "/test", nil, client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected to wait for Retry-After, retried after %s", elapsed)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestRetryWaitStopsOnCancel(t *testing.T) {
+	baseURL, attempts := newRetryServer(t, "60", http.StatusServiceUnavailable)
+	client := newRetryClient(t, baseURL, 3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := MakeMarketplaceRequest[map[string]any](ctx, http.MethodGet, "/test", nil, client)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the wait to stop at the deadline, got %v", err)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	withHeader := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{value}}}
+	}
+
+	tests := []struct {
+		name     string
+		attempt  int
+		res      *http.Response
+		min, max time.Duration
+	}{
+		{name: "delay seconds", res: withHeader("7"), min: 7 * time.Second, max: 7 * time.Second},
+		{name: "http date", res: withHeader(time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)), min: 8 * time.Second, max: 10 * time.Second},
+		{name: "date in the past", res: withHeader("Mon, 02 Jan 2006 15:04:05 GMT"), min: 0, max: 0},
+		{name: "capped", res: withHeader("3600"), min: retryAfterMax, max: retryAfterMax},
+		{name: "invalid falls back to backoff", res: withHeader("soon"), min: retryWaitMin / 2, max: retryWaitMin},
+		{name: "first backoff", attempt: 0, min: retryWaitMin / 2, max: retryWaitMin},
+		{name: "third backoff", attempt: 2, min: 2 * retryWaitMin, max: 4 * retryWaitMin},
+		{name: "backoff is capped", attempt: 40, min: retryWaitMax / 2, max: retryWaitMax},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryWait(tt.attempt, tt.res); got < tt.min || got > tt.max {
+				t.Errorf("expected a wait between %s and %s, got %s", tt.min, tt.max, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA1 seed of RFC 6238 appendix B, "12345678901234567890" in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateTOTPVectors(t *testing.T) {
+	// RFC 6238 appendix B lists 8 digit codes, GenerateTOTP returns the last 6 of them
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+
+	for _, tt := range tests {
+		got, err := GenerateTOTP(rfc6238Secret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatalf("%d: unexpected error: %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("%d: expected %s, got %s", tt.unix, tt.want, got)
+		}
+	}
+}
+
+func TestGenerateTOTPSecretFormats(t *testing.T) {
+	at := time.Unix(1234567890, 0)
+
+	tests := []struct {
+		name   string
+		secret string
+		// same is a canonical secret that has to give the same code
+		same string
+	}{
+		{name: "lowercase", secret: "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", same: rfc6238Secret},
+		{name: "spaces", secret: " GEZD GNBV GY3T QOJQ GEZD GNBV GY3T QOJQ ", same: rfc6238Secret},
+		{name: "lowercase with spaces", secret: "gezd gnbv gy3t qojq gezd gnbv gy3t qojq", same: rfc6238Secret},
+		// 21 bytes need 6 characters of padding
+		{name: "missing padding", secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGE", same: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGE======"},
+		{name: "lowercase without padding", secret: "gezd gnbv gy3t qojq gezd gnbv gy3t qojq ge", same: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGE======"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := GenerateTOTP(tt.same, at)
+			if err != nil {
+				t.Fatalf("canonical secret: unexpected error: %v", err)
+			}
+			got, err := GenerateTOTP(tt.secret, at)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != want {
+				t.Errorf("expected %s, got %s", want, got)
+			}
+		})
+	}
+}
+
+func TestGenerateTOTPInvalidSecret(t *testing.T) {
+	for _, secret := range []string{"", "   ", "====", "not base32!", "GEZDGNBV1"} {
+		if code, err := GenerateTOTP(secret, time.Now()); err == nil {
+			t.Errorf("%q: expected an error, got %s", secret, code)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultBaseURL = "https://marketplace.otc.t-systems.com/api/v1/seller"
+	DefaultTimeout = 60 * time.Second
+)
+
+// ClientOptions configures how the MarketplaceAPIClient talks to the marketplace. The zero value talks to the public
+// marketplace with the system CA pool and the proxy from the environment.
+type ClientOptions struct {
+	// BaseURL of the seller API, DefaultBaseURL if empty
+	BaseURL string
+	// CAFile is a PEM bundle that's trusted in addition to the system CA pool
+	CAFile string
+	// Insecure skips the TLS certificate verification
+	Insecure bool
+	// ProxyURL overrides HTTPS_PROXY/HTTP_PROXY/NO_PROXY from the environment
+	ProxyURL string
+	// Timeout of a single HTTP request, DefaultTimeout if zero
+	Timeout time.Duration
+	// MaxRetries of transient failures, zero disables retries
+	MaxRetries int
+}
+
+// newHTTPClient builds the http.Client shared by all requests of a MarketplaceAPIClient
+func newHTTPClient(opts ClientOptions) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: opts.Insecure,
+	}
+	if opts.CAFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read CA bundle: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA bundle %s doesn't contain any PEM encoded certificates", opts.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse proxy URL: %w", err)
+		}
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("proxy URL %q must contain a scheme and a host, e.g. http://proxy.example.com:3128", opts.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	if timeout < 0 {
+		return nil, errors.New("timeout can't be negative")
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}, nil
+}
+
+// normalizeBaseURL checks that baseURL is an absolute http(s) URL and strips trailing slashes, since request paths
+// are appended as is
+func normalizeBaseURL(baseURL string) (string, error) {
+	if baseURL == "" {
+		return DefaultBaseURL, nil
+	}
+
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("couldn't parse endpoint: %w", err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("endpoint %q must be an absolute http(s) URL, e.g. %s", baseURL, DefaultBaseURL)
+	}
+	return strings.TrimRight(baseURL, "/"), nil
+}